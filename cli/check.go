@@ -2,33 +2,57 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"jobradar/internal/config"
 	"jobradar/internal/engine"
 	"jobradar/internal/model"
+	"jobradar/internal/templates"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var (
+	checkDryRun bool
+	checkJSON   bool
+)
+
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check for new jobs and send notifications",
-	Long:  `Immediately check for new jobs matching your criteria and send notifications.`,
-	RunE:  runCheck,
+	Long: `Immediately check for new jobs matching your criteria and send notifications.
+
+With --dry-run, jobs are fetched and filtered as usual but no notifications
+are sent and nothing is written to the database, so a new filter config can
+be tried without alerting anyone. Matched jobs are listed with their score
+and the search that matched, best first; jobs already notified are marked
+as seen. The database is not migrated either, so after an upgrade run
+'jobradar db migrate' first.`,
+	RunE: runCheck,
 }
 
 func init() {
+	checkCmd.Flags().BoolVar(&checkDryRun, "dry-run", false, "show matches without notifying or saving anything")
+	checkCmd.Flags().BoolVar(&checkJSON, "json", false, "output dry-run matches as JSON")
 	rootCmd.AddCommand(checkCmd)
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
+	if checkJSON && !checkDryRun {
+		return fmt.Errorf("--json requires --dry-run")
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if checkDryRun {
+		return runDryRun(cfg)
+	}
+
 	// Print header
 	blue := color.New(color.FgBlue, color.Bold)
 	blue.Println("\nJobRadar v1.0.0")
@@ -51,22 +75,96 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	// Print results
-	printStats(stats)
+	printStats(stats, false)
 
 	return nil
 }
 
-func printStats(stats *model.RunStats) {
+func printStats(stats *model.RunStats, dryRun bool) {
 	fmt.Println()
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	green := color.New(color.FgGreen)
-	green.Printf("✅ Check completed in %.1fs\n", stats.DurationSeconds)
+	if dryRun {
+		green.Printf("✅ Dry run completed in %.1fs; nothing was sent or saved\n", stats.DurationSeconds)
+	} else {
+		green.Printf("✅ Check completed in %.1fs\n", stats.DurationSeconds)
+	}
 
 	fmt.Println()
 	fmt.Println("📊 Summary:")
 	fmt.Printf("   • Fetched: %d\n", stats.JobsFetched)
 	fmt.Printf("   • Matched: %d\n", stats.JobsMatched)
-	green.Printf("   • Notified: %d\n", stats.JobsNotified)
+	if dryRun {
+		green.Printf("   • Would notify: %d\n", stats.JobsMatched-stats.JobsSkipped)
+	} else {
+		green.Printf("   • Notified: %d\n", stats.JobsNotified)
+	}
 	fmt.Printf("   • Skipped: %d (already seen)\n", stats.JobsSkipped)
+
+	if failed := stats.FailedSources(); len(failed) > 0 {
+		red := color.New(color.FgRed)
+		fmt.Println()
+		red.Printf("❌ %d of %d sources failed:\n", len(failed), len(stats.Sources))
+		for _, r := range failed {
+			fmt.Printf("   • %s: %s\n", r.Label(), r.ErrorMessage)
+		}
+		if stats.ID > 0 {
+			fmt.Printf("   Run 'jobradar runs --id %d' for details.\n", stats.ID)
+		}
+	}
+}
+
+// runDryRun fetches and filters jobs and prints the matches
+func runDryRun(cfg *config.AppConfig) error {
+	eng, err := engine.NewReadOnly(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+	defer eng.Close()
+
+	if !checkJSON {
+		blue := color.New(color.FgBlue, color.Bold)
+		blue.Println("\nJobRadar v1.0.0 - Dry Run")
+		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		fmt.Println("🔍 Checking for matching jobs (no notifications will be sent)...")
+		fmt.Println()
+	}
+
+	stats, matches, err := eng.DryRun()
+	if err != nil {
+		return fmt.Errorf("dry run failed: %w", err)
+	}
+
+	if checkJSON {
+		return writeJSON(matches)
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No jobs matched.")
+	} else {
+		rowFmt := "%5s  %-20s  %-40s  %-18s  %s\n"
+		gray := color.New(color.FgHiBlack)
+		gray.Printf(rowFmt, "Score", "Search", "Job Title", "Budget", "Status")
+		gray.Println(strings.Repeat("─", 96))
+
+		green := color.New(color.FgGreen)
+		for _, m := range matches {
+			searches := m.SearchName
+			if len(m.Matches) > 1 {
+				searches = fmt.Sprintf("%s +%d", templates.Truncate(16, m.SearchName), len(m.Matches)-1)
+			}
+			c, status := green, "new"
+			if m.Seen {
+				c, status = gray, "seen"
+			}
+			c.Printf(rowFmt, fmt.Sprintf("%.2f", m.MatchScore), templates.Truncate(20, searches),
+				templates.Truncate(40, m.Job.Title), templates.Truncate(18, m.Job.BudgetDisplay()), status)
+			gray.Printf("%5s  keywords: %s\n", "", strings.Join(m.MatchedKeywords, ", "))
+		}
+	}
+
+	printStats(stats, true)
+
+	return nil
 }
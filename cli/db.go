@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/storage"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateTo       int
+	restoreNoBackup bool
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Database maintenance",
+	Long:  `Manage the JobRadar database schema, backups and integrity.`,
+}
+
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply or roll back schema migrations",
+	Long: `Migrate the database schema to the latest version, or to a specific
+version with --to. Migrating to a lower version rolls back newer steps
+and drops the data they hold.`,
+	RunE: runDBMigrate,
+}
+
+var dbStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show schema migration status",
+	Long:  `Display the current schema version and which migrations have been applied.`,
+	RunE:  runDBStatus,
+}
+
+var dbBackupCmd = &cobra.Command{
+	Use:   "backup [file]",
+	Short: "Back up the database",
+	Long: `Copy the SQLite database to a backup file using SQLite's online backup
+API, which is safe while the daemon is running. Without a file argument the
+backup is written to storage.backup.dir with a timestamped name.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDBBackup,
+}
+
+var dbRestoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Restore the database from a backup",
+	Long: `Replace the database contents with a backup file. The backup is
+integrity-checked first, and the current database is backed up to
+storage.backup.dir before it is overwritten unless --no-backup is given.
+Backups from older releases are migrated after restoring.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDBRestore,
+}
+
+var dbVacuumCmd = &cobra.Command{
+	Use:   "vacuum",
+	Short: "Reclaim unused database space",
+	Long:  `Rebuild the database to reclaim the space left by cleaned-up records.`,
+	RunE:  runDBVacuum,
+}
+
+var dbCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check database integrity",
+	Long:  `Run an integrity check and verify the schema version and search index.`,
+	RunE:  runDBCheck,
+}
+
+func init() {
+	dbMigrateCmd.Flags().IntVar(&migrateTo, "to", -1, "target schema version (default latest)")
+	dbRestoreCmd.Flags().BoolVar(&restoreNoBackup, "no-backup", false, "do not back up the current database first")
+
+	dbCmd.AddCommand(dbMigrateCmd)
+	dbCmd.AddCommand(dbStatusCmd)
+	dbCmd.AddCommand(dbBackupCmd)
+	dbCmd.AddCommand(dbRestoreCmd)
+	dbCmd.AddCommand(dbVacuumCmd)
+	dbCmd.AddCommand(dbCheckCmd)
+	rootCmd.AddCommand(dbCmd)
+}
+
+// openStorage loads the config and opens the configured database
+func openStorage() (*config.AppConfig, storage.Store, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return cfg, store, nil
+}
+
+// openStorageUnmigrated opens the configured database without migrating
+// it, for commands that must work on a damaged or outdated database
+func openStorageUnmigrated() (*config.AppConfig, storage.Store, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := storage.Open(cfg.Storage)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return cfg, store, nil
+}
+
+func runDBMigrate(cmd *cobra.Command, args []string) error {
+	_, store, err := openStorageUnmigrated()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	target := migrateTo
+	if target < 0 {
+		target = store.LatestVersion()
+	}
+
+	before, err := store.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	if err := store.MigrateTo(target); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen)
+	if before == target {
+		green.Printf("✅ Schema already at version %d\n", target)
+	} else {
+		green.Printf("✅ Migrated schema from version %d to %d\n", before, target)
+	}
+
+	return nil
+}
+
+func runDBStatus(cmd *cobra.Command, args []string) error {
+	_, store, err := openStorageUnmigrated()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	statuses, err := store.MigrationStatus()
+	if err != nil {
+		return err
+	}
+
+	version, err := store.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	blue := color.New(color.FgBlue, color.Bold)
+	blue.Println("\nJobRadar - Database Status")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	fmt.Printf("   Schema Version: %d (latest %d)\n", version, store.LatestVersion())
+	fmt.Println()
+
+	green := color.New(color.FgGreen)
+	yellow := color.New(color.FgYellow)
+
+	for _, st := range statuses {
+		if st.AppliedAt != nil {
+			green.Printf("   ✅ %3d  %-30s  %s\n", st.Version, st.Name, st.AppliedAt.Format("2006-01-02 15:04:05"))
+		} else {
+			yellow.Printf("   ⏳ %3d  %-30s  pending\n", st.Version, st.Name)
+		}
+	}
+
+	fmt.Println()
+
+	return nil
+}
+
+func runDBBackup(cmd *cobra.Command, args []string) error {
+	cfg, store, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	path := storage.BackupPath(cfg.Storage.Backup.Dir, cfg.Storage.Database, time.Now())
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	if err := store.Backup(path); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen)
+	green.Printf("✅ Database backed up to %s (%s)\n", path, fileSize(path))
+
+	return nil
+}
+
+func runDBRestore(cmd *cobra.Command, args []string) error {
+	// Restoring is how a damaged database is repaired, so open it without
+	// migrating; Restore migrates the restored copy
+	cfg, store, err := openStorageUnmigrated()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if !restoreNoBackup {
+		// Not matched by backup rotation, so it is never deleted automatically
+		safety := strings.TrimSuffix(storage.BackupPath(cfg.Storage.Backup.Dir, cfg.Storage.Database, time.Now()), ".db") + "-pre-restore.db"
+		switch err := store.Backup(safety); {
+		case errors.Is(err, storage.ErrIntegrity):
+			yellow := color.New(color.FgYellow)
+			yellow.Printf("⚠️  Current database is damaged and was not backed up: %v\n", err)
+		case err != nil:
+			return fmt.Errorf("failed to back up current database: %w", err)
+		default:
+			fmt.Printf("💾 Current database backed up to %s\n", safety)
+		}
+	}
+
+	if err := store.Restore(args[0]); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen)
+	green.Printf("✅ Database restored from %s\n", args[0])
+
+	return nil
+}
+
+func runDBVacuum(cmd *cobra.Command, args []string) error {
+	cfg, store, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	sqlite := cfg.Storage.DSN == ""
+	before := fileSize(cfg.Storage.Database)
+
+	if err := store.Vacuum(); err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen)
+	if sqlite {
+		green.Printf("✅ Database vacuumed (%s → %s)\n", before, fileSize(cfg.Storage.Database))
+	} else {
+		green.Println("✅ Database vacuumed")
+	}
+
+	return nil
+}
+
+func runDBCheck(cmd *cobra.Command, args []string) error {
+	// Checking must not change the database, so it is not migrated first
+	_, store, err := openStorageUnmigrated()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	problems, err := store.Check()
+	if err != nil {
+		return err
+	}
+
+	if len(problems) == 0 {
+		green := color.New(color.FgGreen)
+		green.Println("✅ Database is healthy")
+		return nil
+	}
+
+	red := color.New(color.FgRed)
+	for _, p := range problems {
+		red.Printf("❌ %s\n", p)
+	}
+	return fmt.Errorf("database check found %d problem(s)", len(problems))
+}
+
+// fileSize formats the size of a file for display
+func fileSize(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "unknown size"
+	}
+
+	size := float64(info.Size())
+	for _, unit := range []string{"B", "KB", "MB"} {
+		if size < 1024 {
+			return fmt.Sprintf("%.1f %s", size, unit)
+		}
+		size /= 1024
+	}
+	return fmt.Sprintf("%.1f GB", size)
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/export"
+	"jobradar/internal/model"
+	"jobradar/internal/storage"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat  string
+	exportOutput  string
+	exportSince   string
+	exportUntil   string
+	exportSearch  string
+	exportChannel string
+	exportStatus  string
+	exportLimit   int
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <notifications|runs|jobs>",
+	Short: "Export notifications, run logs or stored jobs",
+	Long: `Export notification records, run logs or stored jobs as CSV, JSON,
+NDJSON, or spreadsheet-friendly CSV (xlsx: UTF-8 BOM, CRLF, local dates).
+
+Date filters apply to when records were created: notifications by send
+attempt, runs by start time, and jobs by when they were last seen.
+--search applies to notifications and jobs; --channel and --status apply
+to notifications only.
+
+Examples:
+  jobradar export notifications --since 7d --format xlsx -o week.csv
+  jobradar export jobs --search "Golang API" --format ndjson
+  jobradar export runs --since 2024-01-01 --until 2024-02-01 --format json`,
+	Args:      cobra.ExactValidArgs(1),
+	ValidArgs: []string{"notifications", "runs", "jobs"},
+	RunE:      runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "csv", "output format: csv, json, ndjson or xlsx")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "output file (default stdout)")
+	exportCmd.Flags().StringVar(&exportSince, "since", "", "only records since (e.g. 7d, 12h, 2024-01-31)")
+	exportCmd.Flags().StringVar(&exportUntil, "until", "", "only records before (e.g. 1d, 2024-02-15)")
+	exportCmd.Flags().StringVar(&exportSearch, "search", "", "only records for this search name")
+	exportCmd.Flags().StringVar(&exportChannel, "channel", "", "only notifications sent to this channel")
+	exportCmd.Flags().StringVar(&exportStatus, "status", "", "only notifications with this status (pending, sent, failed, skipped)")
+	exportCmd.Flags().IntVarP(&exportLimit, "limit", "n", 0, "max records to export (default all)")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	table := args[0]
+
+	format, err := export.ParseFormat(exportFormat)
+	if err != nil {
+		return err
+	}
+	since, err := parseTimeFlag(exportSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	until, err := parseTimeFlag(exportUntil)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	status := model.NotifyStatus(exportStatus)
+	switch status {
+	case "", model.NotifyStatusPending, model.NotifyStatusSent, model.NotifyStatusFailed, model.NotifyStatusSkipped:
+	default:
+		return fmt.Errorf("invalid --status %q", exportStatus)
+	}
+	if table != "notifications" && (exportChannel != "" || exportStatus != "") {
+		return fmt.Errorf("--channel and --status only apply to notifications")
+	}
+	if table == "runs" && exportSearch != "" {
+		return fmt.Errorf("--search does not apply to runs")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer store.Close()
+
+	var header []string
+	switch table {
+	case "notifications":
+		header = export.NotifyRecordHeader
+	case "runs":
+		header = export.RunLogHeader
+	case "jobs":
+		header = export.JobHeader
+	}
+
+	var out io.Writer = os.Stdout
+	var file *os.File
+	if exportOutput != "" {
+		file, err = os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		out = file
+	}
+
+	w, err := writeExport(store, table, out, format, header, since, until, status)
+	if file != nil {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write output file: %w", closeErr)
+		}
+		if err != nil {
+			os.Remove(exportOutput)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	// Keep stdout clean for piping; only report when writing to a file
+	if exportOutput != "" {
+		green := color.New(color.FgGreen)
+		green.Fprintf(os.Stderr, "✅ Exported %d %s to %s\n", w.Rows(), table, exportOutput)
+	}
+
+	return nil
+}
+
+// writeExport streams the records of a table to out, converting and
+// writing each row as it is read from the database
+func writeExport(store storage.Store, table string, out io.Writer, format export.Format, header []string,
+	since, until time.Time, status model.NotifyStatus) (*export.Writer, error) {
+	w, err := export.NewWriter(out, format, header)
+	if err != nil {
+		return nil, err
+	}
+
+	switch table {
+	case "notifications":
+		err = store.EachNotifyRecord(model.NotifyQuery{
+			Since:      since,
+			Until:      until,
+			SearchName: exportSearch,
+			Channel:    exportChannel,
+			Status:     status,
+			Limit:      exportLimit,
+		}, func(r *model.NotifyRecord) error {
+			return w.Write(export.NotifyRecordRow(r))
+		})
+	case "runs":
+		err = store.EachRunLog(model.RunLogQuery{Since: since, Until: until, Limit: exportLimit}, func(r *model.RunStats) error {
+			return w.Write(export.RunLogRow(r))
+		})
+	case "jobs":
+		err = store.EachJob(model.JobQuery{
+			Since:      since,
+			Until:      until,
+			SearchName: exportSearch,
+			Limit:      exportLimit,
+		}, func(r *model.JobRecord) error {
+			return w.Write(export.JobRow(r))
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return w, w.Close()
+}
@@ -8,24 +8,35 @@ import (
 	"jobradar/internal/config"
 	"jobradar/internal/model"
 	"jobradar/internal/storage"
+	"jobradar/internal/templates"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var (
-	historyLimit int
+	historyLimit  int
+	historyFailed bool
 )
 
 var historyCmd = &cobra.Command{
 	Use:   "history",
 	Short: "View notification history",
-	Long:  `Display the history of job notifications that have been sent.`,
-	RunE:  runHistory,
+	Long: `Display the history of job notifications, one row per job and channel.
+
+Failed notifications are retried on later checks with backoff. Use --failed
+to see what is stuck: notifications waiting for a retry and the ones that
+gave up, with the last error.
+
+Examples:
+  jobradar history
+  jobradar history --failed`,
+	RunE: runHistory,
 }
 
 func init() {
 	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "number of records to display")
+	historyCmd.Flags().BoolVar(&historyFailed, "failed", false, "only failed notifications and pending retries")
 	rootCmd.AddCommand(historyCmd)
 }
 
@@ -35,13 +46,13 @@ func runHistory(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	store, err := storage.New(cfg.Storage.Database)
+	store, err := storage.New(cfg.Storage)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
 	defer store.Close()
 
-	records, err := store.GetNotifyRecords(historyLimit)
+	records, err := store.QueryNotifyRecords(model.NotifyQuery{Limit: historyLimit, Stuck: historyFailed})
 	if err != nil {
 		return fmt.Errorf("failed to get records: %w", err)
 	}
@@ -51,7 +62,11 @@ func runHistory(cmd *cobra.Command, args []string) error {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
 	if len(records) == 0 {
-		fmt.Println("\nNo notification records found.")
+		if historyFailed {
+			fmt.Println("\nNo failed notifications. ✨")
+		} else {
+			fmt.Println("\nNo notification records found.")
+		}
 		return nil
 	}
 
@@ -67,43 +82,48 @@ func runHistory(cmd *cobra.Command, args []string) error {
 
 	green := color.New(color.FgGreen)
 	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
 
 	for _, r := range records {
 		timeStr := r.CreatedAt.Format("2006-01-02 15:04:05")
-		title := truncateString(r.JobTitle, 38)
+		title := templates.Truncate(38, r.JobTitle)
 		channel := r.NotifyChannel
 
-		var status string
-		if r.Status == model.NotifyStatusSent {
-			status = "✅ Sent"
-		} else if r.Status == model.NotifyStatusFailed {
-			status = "❌ Failed"
-		} else {
-			status = string(r.Status)
+		switch {
+		case r.Status == model.NotifyStatusSent:
+			green.Printf(rowFmt, timeStr, title, channel, "✅ Sent")
+		case r.Status == model.NotifyStatusFailed:
+			red.Printf(rowFmt, timeStr, title, channel, fmt.Sprintf("❌ Failed after %d", r.Attempts))
+		case r.Status == model.NotifyStatusPending && r.Attempts > 0:
+			status := fmt.Sprintf("⏳ Retry %d", r.Attempts+1)
+			if r.NextAttemptAt != nil {
+				status += " at " + r.NextAttemptAt.Format("15:04")
+			}
+			yellow.Printf(rowFmt, timeStr, title, channel, status)
+		case r.Status == model.NotifyStatusPending && r.NextAttemptAt != nil && r.NextAttemptAt.After(time.Now()):
+			// Held for a digest
+			fmt.Printf(rowFmt, timeStr, title, channel, "⏳ Queued for "+r.NextAttemptAt.Format("15:04"))
+		case r.Status == model.NotifyStatusPending:
+			fmt.Printf(rowFmt, timeStr, title, channel, "⏳ Queued")
+		default:
+			fmt.Printf(rowFmt, timeStr, title, channel, string(r.Status))
 		}
 
-		if r.Status == model.NotifyStatusSent {
-			green.Printf(rowFmt, timeStr, title, channel, status)
-		} else if r.Status == model.NotifyStatusFailed {
-			red.Printf(rowFmt, timeStr, title, channel, status)
-		} else {
-			fmt.Printf(rowFmt, timeStr, title, channel, status)
+		if r.Stuck() && r.ErrorMessage != "" {
+			gray.Printf("%22s%s\n", "", templates.Truncate(80, r.ErrorMessage))
 		}
 	}
 
 	fmt.Println()
-	fmt.Printf("Showing %d most recent notifications\n", len(records))
+	if historyFailed {
+		fmt.Printf("Showing %d most recent failed notifications\n", len(records))
+	} else {
+		fmt.Printf("Showing %d most recent notifications\n", len(records))
+	}
 
 	return nil
 }
 
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
-}
-
 // formatTimeAgo formats a time as a relative string
 func formatTimeAgo(t time.Time) string {
 	delta := time.Since(t)
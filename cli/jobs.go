@@ -0,0 +1,402 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"jobradar/internal/model"
+	"jobradar/internal/templates"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	markBid      float64
+	markConnects int
+	markNote     string
+	jobsStatus   string
+	jobsOpen     bool
+	jobsSearch   string
+	jobsLimit    int
+	jobsJSON     bool
+)
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Track the jobs you act on",
+	Long: `Record what you did after an alert: save a job, apply with a bid and the
+connects spent, follow it through interviews to hired or lost, and keep
+notes along the way. Jobs are referred to by ID or URL.
+
+Statuses: new, saved, applied, interviewing, hired, lost, ignored.
+Tracked jobs are never removed by storage retention.`,
+}
+
+var jobsMarkCmd = &cobra.Command{
+	Use:   "mark <job-id|url> <status>",
+	Short: "Set the status of a job",
+	Long: `Set the status of a job, starting to track it if needed.
+
+Examples:
+  jobradar jobs mark ~01abc saved
+  jobradar jobs mark ~01abc applied --bid 450 --connects 16
+  jobradar jobs mark https://www.upwork.com/jobs/~01abc lost --note "went with an agency"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runJobsMark,
+}
+
+var jobsNoteCmd = &cobra.Command{
+	Use:   "note <job-id|url> <text>",
+	Short: "Add a note to a job",
+	Long:  `Add a note to a job, starting to track it as new if needed.`,
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runJobsNote,
+}
+
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tracked jobs",
+	Long:  `List tracked jobs, most recently updated first.`,
+	RunE:  runJobsList,
+}
+
+var jobsShowCmd = &cobra.Command{
+	Use:   "show <job-id|url>",
+	Short: "Show a tracked job with notes and history",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runJobsShow,
+}
+
+var jobsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show win rates by search and keyword",
+	Long: `Show how tracked proposals turned out. The win rate is hires per
+proposal sent, where a proposal counts once a job reaches applied.`,
+	RunE: runJobsStats,
+}
+
+func init() {
+	jobsMarkCmd.Flags().Float64Var(&markBid, "bid", 0, "bid amount (fixed price or hourly rate)")
+	jobsMarkCmd.Flags().IntVar(&markConnects, "connects", 0, "total connects spent on the proposal")
+	jobsMarkCmd.Flags().StringVar(&markNote, "note", "", "add a note")
+
+	jobsListCmd.Flags().StringVar(&jobsStatus, "status", "", "only these statuses (comma-separated)")
+	jobsListCmd.Flags().BoolVar(&jobsOpen, "open", false, "only jobs that are not hired, lost or ignored")
+	jobsListCmd.Flags().StringVar(&jobsSearch, "search", "", "only jobs matched by this search")
+	jobsListCmd.Flags().IntVarP(&jobsLimit, "limit", "n", 50, "number of jobs to display (0 for all)")
+	jobsListCmd.Flags().BoolVar(&jobsJSON, "json", false, "output as JSON")
+
+	jobsShowCmd.Flags().BoolVar(&jobsJSON, "json", false, "output as JSON")
+	jobsStatsCmd.Flags().BoolVar(&jobsJSON, "json", false, "output as JSON")
+
+	jobsCmd.AddCommand(jobsMarkCmd)
+	jobsCmd.AddCommand(jobsNoteCmd)
+	jobsCmd.AddCommand(jobsListCmd)
+	jobsCmd.AddCommand(jobsShowCmd)
+	jobsCmd.AddCommand(jobsStatsCmd)
+	rootCmd.AddCommand(jobsCmd)
+}
+
+func runJobsMark(cmd *cobra.Command, args []string) error {
+	status, err := model.ParseTrackStatus(args[1])
+	if err != nil {
+		return err
+	}
+
+	u := model.TrackUpdate{JobRef: args[0], Status: status, Note: markNote}
+	if cmd.Flags().Changed("bid") {
+		if markBid < 0 {
+			return fmt.Errorf("--bid must not be negative")
+		}
+		u.Bid = &markBid
+	}
+	if cmd.Flags().Changed("connects") {
+		if markConnects < 0 {
+			return fmt.Errorf("--connects must not be negative")
+		}
+		u.Connects = &markConnects
+	}
+
+	return updateTrackedJob(u)
+}
+
+func runJobsNote(cmd *cobra.Command, args []string) error {
+	note := strings.TrimSpace(strings.Join(args[1:], " "))
+	if note == "" {
+		return fmt.Errorf("note is empty")
+	}
+	return updateTrackedJob(model.TrackUpdate{JobRef: args[0], Note: note})
+}
+
+// updateTrackedJob applies u and prints the result
+func updateTrackedJob(u model.TrackUpdate) error {
+	_, store, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	t, err := store.UpdateTrackedJob(u)
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen)
+	green.Printf("✅ %s: %s\n", t.Status, t.JobTitle)
+	if u.Note != "" {
+		fmt.Printf("   Note added (%d total)\n", len(t.Notes))
+	}
+	return nil
+}
+
+func runJobsList(cmd *cobra.Command, args []string) error {
+	q := model.TrackQuery{SearchName: jobsSearch, Limit: jobsLimit}
+	if jobsStatus != "" {
+		for _, name := range strings.Split(jobsStatus, ",") {
+			status, err := model.ParseTrackStatus(name)
+			if err != nil {
+				return err
+			}
+			q.Statuses = append(q.Statuses, status)
+		}
+	}
+	if jobsOpen {
+		if len(q.Statuses) > 0 {
+			return fmt.Errorf("--open and --status cannot be combined")
+		}
+		for _, status := range model.TrackStatuses {
+			if !status.Closed() {
+				q.Statuses = append(q.Statuses, status)
+			}
+		}
+	}
+
+	_, store, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	jobs, err := store.QueryTrackedJobs(q)
+	if err != nil {
+		return err
+	}
+
+	if jobsJSON {
+		if jobs == nil {
+			jobs = []*model.TrackedJob{}
+		}
+		return writeJSON(jobs)
+	}
+
+	blue := color.New(color.FgBlue, color.Bold)
+	blue.Println("\nJobRadar - Tracked Jobs")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if len(jobs) == 0 {
+		fmt.Println("\nNo tracked jobs found.")
+		return nil
+	}
+
+	fmt.Println()
+
+	rowFmt := "%-12s  %-40s  %-8s  %-8s  %-12s\n"
+	gray := color.New(color.FgHiBlack)
+	gray.Printf(rowFmt, "Status", "Job Title", "Bid", "Connects", "Updated")
+	gray.Println(strings.Repeat("─", 88))
+
+	for _, t := range jobs {
+		bid := "-"
+		if t.Bid != nil {
+			bid = fmt.Sprintf("$%.0f", *t.Bid)
+		}
+		statusColor(t.Status).Printf(rowFmt, t.Status, templates.Truncate(38, t.JobTitle), bid,
+			fmt.Sprintf("%d", t.Connects), formatTimeAgo(t.UpdatedAt))
+	}
+
+	fmt.Println()
+	fmt.Printf("Showing %d tracked jobs\n", len(jobs))
+
+	return nil
+}
+
+func runJobsShow(cmd *cobra.Command, args []string) error {
+	_, store, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	t, err := store.GetTrackedJob(args[0])
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return fmt.Errorf("job %s is not tracked", args[0])
+	}
+
+	if jobsJSON {
+		return writeJSON(t)
+	}
+
+	bold := color.New(color.Bold)
+	cyan := color.New(color.FgCyan)
+	gray := color.New(color.FgHiBlack)
+
+	fmt.Println()
+	bold.Println(t.JobTitle)
+	if t.JobURL != "" {
+		gray.Println(t.JobURL)
+	}
+	fmt.Println()
+
+	cyan.Print("   Status:     ")
+	statusColor(t.Status).Println(t.Status)
+	if t.Bid != nil {
+		cyan.Print("   Bid:        ")
+		fmt.Printf("$%.2f\n", *t.Bid)
+	}
+	cyan.Print("   Connects:   ")
+	fmt.Printf("%d\n", t.Connects)
+	if len(t.Searches) > 0 {
+		cyan.Print("   Searches:   ")
+		fmt.Println(strings.Join(t.Searches, ", "))
+	}
+	if len(t.Keywords) > 0 {
+		cyan.Print("   Keywords:   ")
+		fmt.Println(strings.Join(t.Keywords, ", "))
+	}
+	cyan.Print("   Tracked:    ")
+	fmt.Println(t.CreatedAt.Format("2006-01-02 15:04"))
+	if t.AppliedAt != nil {
+		cyan.Print("   Applied:    ")
+		fmt.Println(t.AppliedAt.Format("2006-01-02 15:04"))
+	}
+	if t.ClosedAt != nil {
+		cyan.Print("   Closed:     ")
+		fmt.Println(t.ClosedAt.Format("2006-01-02 15:04"))
+	}
+
+	if len(t.History) > 0 {
+		fmt.Println()
+		fmt.Println("📅 History:")
+		for _, c := range t.History {
+			fmt.Printf("   %s  %s\n", c.ChangedAt.Format("2006-01-02 15:04"), c.Status)
+		}
+	}
+
+	if len(t.Notes) > 0 {
+		fmt.Println()
+		fmt.Println("📝 Notes:")
+		for _, n := range t.Notes {
+			gray.Printf("   %s  ", n.CreatedAt.Format("2006-01-02 15:04"))
+			fmt.Println(n.Body)
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func runJobsStats(cmd *cobra.Command, args []string) error {
+	_, store, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	report, err := store.GetWinRates()
+	if err != nil {
+		return err
+	}
+
+	if jobsJSON {
+		return writeJSON(report)
+	}
+
+	blue := color.New(color.FgBlue, color.Bold)
+	blue.Println("\nJobRadar - Win Rates")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	o := report.Overall
+	if o.Tracked == 0 {
+		fmt.Println("No tracked jobs yet. Use 'jobradar jobs mark' after acting on an alert.")
+		return nil
+	}
+
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+
+	cyan.Print("   Tracked:           ")
+	fmt.Printf("%d\n", o.Tracked)
+	cyan.Print("   Proposals Sent:    ")
+	fmt.Printf("%d\n", o.Applied)
+	cyan.Print("   Interviewing:      ")
+	fmt.Printf("%d\n", o.Interviewing)
+	cyan.Print("   Hired / Lost:      ")
+	fmt.Printf("%d / %d\n", o.Hired, o.Lost)
+	cyan.Print("   Win Rate:          ")
+	green.Printf("%.1f%%\n", o.Rate()*100)
+	cyan.Print("   Connects Spent:    ")
+	fmt.Printf("%d", o.Connects)
+	if o.Hired > 0 {
+		fmt.Printf(" (%.0f per hire)", o.ConnectsPerHire())
+	}
+	fmt.Println()
+	if o.AvgBid > 0 {
+		cyan.Print("   Average Bid:       ")
+		fmt.Printf("$%.0f\n", o.AvgBid)
+	}
+	fmt.Println()
+
+	printWinRates("🔎 By Search:", report.BySearch)
+	printWinRates("🏷️  By Keyword:", report.ByKeyword)
+
+	return nil
+}
+
+// printWinRates prints one line per search or keyword with proposals sent
+func printWinRates(title string, rates []*model.WinRate) {
+	var applied []*model.WinRate
+	for _, w := range rates {
+		if w.Applied > 0 {
+			applied = append(applied, w)
+		}
+	}
+	if len(applied) == 0 {
+		return
+	}
+
+	cyan := color.New(color.FgCyan)
+	fmt.Println(title)
+	fmt.Println()
+	for _, w := range applied {
+		cyan.Printf("   %-30s ", templates.Truncate(30, w.Name))
+		fmt.Printf("%3d sent  %3d hired  %5.1f%%  %4d connects\n", w.Applied, w.Hired, w.Rate()*100, w.Connects)
+	}
+	fmt.Println()
+}
+
+// statusColor returns the color used to print a tracked job status
+func statusColor(status model.TrackStatus) *color.Color {
+	switch status {
+	case model.TrackStatusHired:
+		return color.New(color.FgGreen)
+	case model.TrackStatusLost, model.TrackStatusIgnored:
+		return color.New(color.FgHiBlack)
+	case model.TrackStatusApplied, model.TrackStatusInterviewing:
+		return color.New(color.FgYellow)
+	}
+	return color.New(color.Reset)
+}
+
+// writeJSON prints v to stdout as indented JSON
+func writeJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
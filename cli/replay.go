@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"jobradar/internal/config"
+	"jobradar/internal/engine"
+	"jobradar/internal/templates"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replaySince  string
+	replayUntil  string
+	replaySearch string
+	replayLimit  int
+	replayJSON   bool
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay stored jobs through the current filters",
+	Long: `Run the filters and searches of a config over jobs JobRadar has already
+stored, and compare the result with what was actually notified. Use it to see
+the effect of a filter change before it goes live.
+
+The config's storage section picks the database, so a candidate config
+should point at the same database as the live one. Nothing is sent or saved,
+and the database is not migrated, so after an upgrade run 'jobradar db
+migrate' first.
+
+Examples:
+  jobradar replay --since 7d
+  jobradar replay --config new.yaml --since 7d
+  jobradar replay --config new.yaml --since 2w --search "Golang" --json`,
+	RunE: runReplay,
+}
+
+func init() {
+	replayCmd.Flags().StringVar(&replaySince, "since", "7d", "replay jobs seen since (e.g. 7d, 12h, 2024-01-31)")
+	replayCmd.Flags().StringVar(&replayUntil, "until", "", "replay jobs seen before (e.g. 1d, 2024-02-15)")
+	replayCmd.Flags().StringVar(&replaySearch, "search", "", "only show this search")
+	replayCmd.Flags().IntVarP(&replayLimit, "limit", "n", 5, "jobs to list per search and change, 0 for all")
+	replayCmd.Flags().BoolVar(&replayJSON, "json", false, "output as JSON")
+	rootCmd.AddCommand(replayCmd)
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	since, err := parseTimeFlag(replaySince)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	until, err := parseTimeFlag(replayUntil)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	eng, err := engine.NewReadOnly(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create engine: %w", err)
+	}
+	defer eng.Close()
+
+	report, err := eng.Replay(since, until)
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+
+	if replaySearch != "" {
+		var searches []*engine.SearchDiff
+		for _, d := range report.Searches {
+			if strings.EqualFold(d.SearchName, replaySearch) {
+				searches = append(searches, d)
+			}
+		}
+		if len(searches) == 0 {
+			return fmt.Errorf("search %q is not configured and notified nothing", replaySearch)
+		}
+		report.Searches = searches
+	}
+
+	if replayJSON {
+		return writeJSON(report)
+	}
+
+	printReplay(report)
+	return nil
+}
+
+// printReplay prints a replay report with the jobs that changed per search
+func printReplay(report *engine.ReplayReport) {
+	blue := color.New(color.FgBlue, color.Bold)
+	blue.Println("\nJobRadar - Replay")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	cyan := color.New(color.FgCyan)
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+	gray := color.New(color.FgHiBlack)
+
+	window := report.Since.Format("2006-01-02 15:04")
+	if report.Until.IsZero() {
+		window += " → now"
+	} else {
+		window += " → " + report.Until.Format("2006-01-02 15:04")
+	}
+
+	cyan.Print("   Window:     ")
+	fmt.Println(window)
+	cyan.Print("   Replayed:   ")
+	fmt.Printf("%d stored jobs\n", report.JobsReplayed)
+	cyan.Print("   Notified:   ")
+	fmt.Printf("%d jobs\n", report.JobsNotified)
+	cyan.Print("   Would match:")
+	fmt.Printf(" %d jobs, %d never notified\n", report.JobsMatched, report.NewNotifications)
+	if report.NotStored > 0 {
+		gray.Printf("   %d notified jobs are no longer stored and were left out\n", report.NotStored)
+	}
+	fmt.Println()
+
+	if report.JobsReplayed == 0 {
+		fmt.Println("No stored jobs in this window.")
+		return
+	}
+
+	rowFmt := "%-30s  %8s  %11s  %6s  %6s  %8s\n"
+	gray.Printf(rowFmt, "Search", "Notified", "Would match", "Kept", "+New", "-Dropped")
+	gray.Println(strings.Repeat("─", 80))
+
+	for _, d := range report.Searches {
+		line := fmt.Sprintf(rowFmt, templates.Truncate(30, d.SearchName), fmt.Sprintf("%d", d.Notified),
+			fmt.Sprintf("%d", d.Matched), fmt.Sprintf("%d", d.Kept),
+			fmt.Sprintf("+%d", len(d.Added)), fmt.Sprintf("-%d", len(d.Dropped)))
+		if d.Changed() {
+			fmt.Print(line)
+		} else {
+			gray.Print(line)
+		}
+	}
+
+	for _, d := range report.Searches {
+		if !d.Changed() {
+			continue
+		}
+		fmt.Println()
+		blue.Printf("%s\n", d.SearchName)
+		printReplayJobs(d.Added, "+", green)
+		printReplayJobs(d.Dropped, "-", red)
+	}
+
+	fmt.Println()
+	if report.NewNotifications > 0 {
+		fmt.Printf("This config would have sent %d notifications that were never sent.\n", report.NewNotifications)
+	}
+}
+
+// printReplayJobs lists up to replayLimit jobs with a change marker
+func printReplayJobs(jobs []*engine.ReplayJob, mark string, c *color.Color) {
+	gray := color.New(color.FgHiBlack)
+	for i, job := range jobs {
+		if replayLimit > 0 && i == replayLimit {
+			gray.Printf("   ... and %d more\n", len(jobs)-replayLimit)
+			break
+		}
+		c.Printf("   %s %.2f  %s\n", mark, job.Score, templates.Truncate(60, job.Title))
+		if len(job.Keywords) > 0 {
+			gray.Printf("          %s\n", strings.Join(job.Keywords, ", "))
+		}
+	}
+}
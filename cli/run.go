@@ -38,12 +38,21 @@ func runScheduler(cmd *cobra.Command, args []string) error {
 	fmt.Printf("⏰ Starting scheduler (every %d minutes)\n", cfg.Schedule.IntervalMinutes)
 
 	if cfg.Schedule.QuietHours.Enabled {
-		fmt.Printf("🌙 Quiet hours: %s - %s (%s)\n",
+		fmt.Printf("🌙 Quiet hours: %s - %s (%s), checks continue and notifications are held\n",
 			cfg.Schedule.QuietHours.Start,
 			cfg.Schedule.QuietHours.End,
 			cfg.Schedule.QuietHours.Timezone)
 	}
 
+	if cfg.Notifications.Telegram.Bot.Enabled {
+		fmt.Println("🤖 Telegram bot: answering commands and alert buttons")
+	}
+
+	if backup := cfg.Storage.Backup; backup.Enabled {
+		fmt.Printf("💾 Backups: every %d hours to %s (keep %d)\n",
+			backup.IntervalHours, backup.Dir, backup.Keep)
+	}
+
 	fmt.Println()
 	fmt.Println("Press Ctrl+C to stop")
 	fmt.Println()
@@ -63,6 +72,9 @@ func runScheduler(cmd *cobra.Command, args []string) error {
 
 	// Start scheduler
 	eng.StartScheduler()
+	if cfg.Notifications.Telegram.Enabled && cfg.Notifications.Telegram.Bot.Enabled {
+		eng.StartBot()
+	}
 
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
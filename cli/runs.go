@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"jobradar/internal/model"
+	"jobradar/internal/templates"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runsID     int64
+	runsLimit  int
+	runsFailed bool
+	runsJSON   bool
+)
+
+var runsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "View recent check runs",
+	Long: `Display recent check runs with their totals and errors. With --id, show
+one run broken down by source: each search keyword or feed with the jobs
+fetched, how long it took, the HTTP status and any error.
+
+Examples:
+  jobradar runs
+  jobradar runs --failed
+  jobradar runs --id 42`,
+	RunE: runRuns,
+}
+
+func init() {
+	runsCmd.Flags().Int64Var(&runsID, "id", 0, "show the source breakdown of this run")
+	runsCmd.Flags().IntVarP(&runsLimit, "limit", "n", 20, "number of runs to display")
+	runsCmd.Flags().BoolVar(&runsFailed, "failed", false, "only runs that recorded an error")
+	runsCmd.Flags().BoolVar(&runsJSON, "json", false, "output as JSON")
+	rootCmd.AddCommand(runsCmd)
+}
+
+func runRuns(cmd *cobra.Command, args []string) error {
+	_, store, err := openStorage()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if runsID > 0 {
+		run, err := store.GetRunLog(runsID)
+		if err != nil {
+			return err
+		}
+		if run == nil {
+			return fmt.Errorf("run %d not found", runsID)
+		}
+		if runsJSON {
+			return writeJSON(run)
+		}
+		printRun(run)
+		return nil
+	}
+
+	runs, err := store.QueryRunLogs(model.RunLogQuery{Limit: runsLimit, Failed: runsFailed})
+	if err != nil {
+		return err
+	}
+
+	if runsJSON {
+		if runs == nil {
+			runs = []*model.RunStats{}
+		}
+		return writeJSON(runs)
+	}
+
+	blue := color.New(color.FgBlue, color.Bold)
+	blue.Println("\nJobRadar - Runs")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	if len(runs) == 0 {
+		fmt.Println("\nNo runs found.")
+		return nil
+	}
+
+	fmt.Println()
+
+	rowFmt := "%-6s  %-19s  %8s  %7s  %7s  %8s  %s\n"
+	gray := color.New(color.FgHiBlack)
+	gray.Printf(rowFmt, "ID", "Started", "Duration", "Fetched", "Matched", "Notified", "Status")
+	gray.Println(strings.Repeat("─", 90))
+
+	green := color.New(color.FgGreen)
+	red := color.New(color.FgRed)
+
+	for _, r := range runs {
+		status := "✅ OK"
+		c := green
+		if r.ErrorMessage != "" {
+			status = "❌ " + templates.Truncate(30, r.ErrorMessage)
+			c = red
+		}
+		c.Printf(rowFmt, fmt.Sprintf("%d", r.ID), r.StartedAt.Format("2006-01-02 15:04:05"),
+			formatDuration(r.DurationSeconds), fmt.Sprintf("%d", r.JobsFetched),
+			fmt.Sprintf("%d", r.JobsMatched), fmt.Sprintf("%d", r.JobsNotified), status)
+	}
+
+	fmt.Println()
+	fmt.Printf("Showing %d most recent runs. Use --id to see a run by source.\n", len(runs))
+
+	return nil
+}
+
+// printRun prints a run with its per-source breakdown
+func printRun(run *model.RunStats) {
+	blue := color.New(color.FgBlue, color.Bold)
+	blue.Printf("\nJobRadar - Run %d\n", run.ID)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	cyan := color.New(color.FgCyan)
+	red := color.New(color.FgRed)
+
+	cyan.Print("   Started:    ")
+	fmt.Printf("%s (%s)\n", run.StartedAt.Format("2006-01-02 15:04:05"), formatTimeAgo(run.StartedAt))
+	cyan.Print("   Duration:   ")
+	fmt.Println(formatDuration(run.DurationSeconds))
+	cyan.Print("   Jobs:       ")
+	fmt.Printf("%d fetched, %d matched, %d notified, %d skipped\n",
+		run.JobsFetched, run.JobsMatched, run.JobsNotified, run.JobsSkipped)
+	if run.ErrorMessage != "" {
+		cyan.Print("   Error:      ")
+		red.Println(run.ErrorMessage)
+	}
+	fmt.Println()
+
+	if len(run.Sources) == 0 {
+		fmt.Println("No source results were recorded for this run.")
+		return
+	}
+
+	rowFmt := "%-40s  %7s  %8s  %6s\n"
+	gray := color.New(color.FgHiBlack)
+	gray.Printf(rowFmt, "Source", "Fetched", "Duration", "HTTP")
+	gray.Println(strings.Repeat("─", 68))
+
+	for _, r := range run.Sources {
+		status := "-"
+		if r.HTTPStatus > 0 {
+			status = fmt.Sprintf("%d", r.HTTPStatus)
+		}
+		line := fmt.Sprintf(rowFmt, templates.Truncate(40, r.Label()), fmt.Sprintf("%d", r.Fetched),
+			formatDuration(r.DurationSeconds), status)
+		if r.Failed() {
+			red.Print(line)
+			gray.Printf("   %s\n", r.ErrorMessage)
+		} else {
+			fmt.Print(line)
+		}
+	}
+
+	if failed := run.FailedSources(); len(failed) > 0 {
+		fmt.Println()
+		red.Printf("%d of %d sources failed\n", len(failed), len(run.Sources))
+	}
+	fmt.Println()
+}
+
+// formatDuration formats seconds for display, e.g. "850ms" or "12.4s"
+func formatDuration(seconds float64) string {
+	if seconds < 1 {
+		return fmt.Sprintf("%.0fms", seconds*1000)
+	}
+	return fmt.Sprintf("%.1fs", seconds)
+}
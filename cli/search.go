@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+	"jobradar/internal/storage"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchLimit     int
+	searchSince     string
+	searchUntil     string
+	searchBudgetMin float64
+	searchBudgetMax float64
+	searchJSON      bool
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search stored jobs",
+	Long: `Full-text search over the title, description and skills of every job
+JobRadar has stored. Quoted phrases, OR, NOT and prefix* terms are supported.
+
+Ranked results need a binary built with the sqlite_fts5 tag (make build does
+this); otherwise matching falls back to unranked substring search.
+
+Examples:
+  jobradar search "kubernetes migration" --since 7d
+  jobradar search '"react native" OR flutter' --min-budget 1000 --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 20, "number of results to display")
+	searchCmd.Flags().StringVar(&searchSince, "since", "", "only jobs posted since (e.g. 7d, 12h, 2024-01-31)")
+	searchCmd.Flags().StringVar(&searchUntil, "until", "", "only jobs posted before (e.g. 1d, 2024-02-15)")
+	searchCmd.Flags().Float64Var(&searchBudgetMin, "min-budget", 0, "minimum budget or hourly rate")
+	searchCmd.Flags().Float64Var(&searchBudgetMax, "max-budget", 0, "maximum budget or hourly rate")
+	searchCmd.Flags().BoolVar(&searchJSON, "json", false, "output results as JSON")
+	rootCmd.AddCommand(searchCmd)
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	since, err := parseTimeFlag(searchSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	until, err := parseTimeFlag(searchUntil)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer store.Close()
+
+	q := model.JobSearch{
+		Query:          strings.Join(args, " "),
+		Since:          since,
+		Until:          until,
+		BudgetMin:      searchBudgetMin,
+		BudgetMax:      searchBudgetMax,
+		Limit:          searchLimit,
+		HighlightStart: "**",
+		HighlightEnd:   "**",
+	}
+	if !searchJSON && !color.NoColor {
+		q.HighlightStart = "\x1b[1;33m"
+		q.HighlightEnd = "\x1b[0m"
+	}
+
+	results, err := store.SearchJobs(q)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+
+	if searchJSON {
+		if results == nil {
+			results = []*model.JobSearchResult{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+
+	blue := color.New(color.FgBlue, color.Bold)
+	blue.Printf("\nJobRadar - Search: %s\n", q.Query)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	gray := color.New(color.FgHiBlack)
+	if !store.FullTextSearch() {
+		gray.Println("Full-text index unavailable; showing unranked substring matches.")
+	}
+
+	if len(results) == 0 {
+		fmt.Println("\nNo matching jobs found.")
+		return nil
+	}
+
+	bold := color.New(color.Bold)
+	for i, r := range results {
+		job := r.Job
+
+		fmt.Println()
+		bold.Printf("%d. %s\n", i+1, job.Title)
+
+		details := []string{job.BudgetDisplay()}
+		if !job.PostedAt.IsZero() {
+			details = append(details, formatTimeAgo(job.PostedAt))
+		}
+		if len(job.Skills) > 0 {
+			details = append(details, strings.Join(job.Skills, ", "))
+		}
+		if store.FullTextSearch() {
+			details = append(details, fmt.Sprintf("rank %.2f", r.Rank))
+		}
+		gray.Printf("   %s\n", strings.Join(details, " · "))
+
+		if r.Snippet != "" {
+			fmt.Printf("   %s\n", r.Snippet)
+		}
+		if job.URL != "" {
+			fmt.Printf("   %s\n", job.URL)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Showing %d matching jobs\n", len(results))
+
+	return nil
+}
+
+// parseTimeFlag parses a time flag given either as a duration ago (7d, 2w,
+// 12h, 30m) or as a date (2006-01-02). An empty value gives the zero time.
+func parseTimeFlag(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	// time.ParseDuration has no day or week units
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, err := strconv.Atoi(strings.TrimSuffix(value, suffix)); err == nil && strings.HasSuffix(value, suffix) {
+			return time.Now().Add(-time.Duration(n) * unit), nil
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a duration like 7d or a date like 2006-01-02", value)
+	}
+	return time.Now().Add(-d), nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"jobradar/internal/config"
 	"jobradar/internal/storage"
+	"jobradar/internal/templates"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -27,7 +28,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	store, err := storage.New(cfg.Storage.Database)
+	store, err := storage.New(cfg.Storage)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -63,6 +64,11 @@ func runStats(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 
+	if stats.TrackingSince != nil {
+		cyan.Print("   Tracking Since:    ")
+		fmt.Printf("%s\n", stats.TrackingSince.Format("2006-01-02"))
+	}
+
 	if stats.LastRunAt != nil {
 		cyan.Print("   Last Run:          ")
 		fmt.Printf("%s (%s)\n", stats.LastRunAt.Format("2006-01-02 15:04:05"), formatTimeAgo(*stats.LastRunAt))
@@ -91,5 +97,46 @@ func runStats(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 
+	searchStats, err := store.GetSearchStats()
+	if err != nil {
+		return fmt.Errorf("failed to get search stats: %w", err)
+	}
+
+	if len(searchStats) > 0 {
+		fmt.Println("🔎 Matches by Search:")
+		fmt.Println()
+
+		for _, st := range searchStats {
+			cyan.Printf("   %-30s ", templates.Truncate(30, st.SearchName))
+			fmt.Printf("%4d  (avg score %.2f", st.TotalMatches, st.AvgScore)
+			if st.LastMatchAt != nil {
+				fmt.Printf(", last %s", formatTimeAgo(*st.LastMatchAt))
+			}
+			fmt.Println(")")
+		}
+
+		fmt.Println()
+	}
+
+	winRates, err := store.GetWinRates()
+	if err != nil {
+		return fmt.Errorf("failed to get win rates: %w", err)
+	}
+
+	if o := winRates.Overall; o.Applied > 0 {
+		fmt.Println("🏆 Proposals:")
+		fmt.Println()
+
+		cyan.Print("   Proposals Sent:    ")
+		fmt.Printf("%d\n", o.Applied)
+
+		cyan.Print("   Win Rate:          ")
+		green.Printf("%.1f%% (%d hired)\n", o.Rate()*100, o.Hired)
+
+		fmt.Println()
+		fmt.Println("   Run 'jobradar jobs stats' for a breakdown by search and keyword.")
+		fmt.Println()
+	}
+
 	return nil
 }
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"jobradar/internal/templates"
+
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates [name]",
+	Short: "Print the built-in notification templates",
+	Long: `Print a built-in notification template, to copy as the starting point for
+notifications.telegram.template, notifications.email.template or
+notifications.email.text_template.
+
+Without a name, lists the built-in templates.
+
+Examples:
+  jobradar templates
+  jobradar templates telegram.tmpl > telegram.tmpl`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTemplates,
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+}
+
+func runTemplates(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("Built-in templates:")
+		for _, name := range templates.Defaults() {
+			fmt.Printf("  %s\n", name)
+		}
+		return nil
+	}
+
+	src, err := templates.Default(args[0])
+	if err != nil {
+		return fmt.Errorf("%w (available: %s)", err, strings.Join(templates.Defaults(), ", "))
+	}
+	fmt.Print(src)
+	return nil
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"jobradar/internal/config"
 	"jobradar/internal/engine"
@@ -70,25 +71,63 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		fmt.Printf("      • Max Proposals: %d\n", *cfg.Filters.MaxProposals)
 	}
 	fmt.Printf("      • Exclude Keywords: %d\n", len(cfg.Filters.ExcludeKeywords))
+	if len(cfg.Filters.Languages) > 0 {
+		fmt.Printf("      • Languages: %s\n", strings.Join(cfg.Filters.Languages, ", "))
+	}
 	fmt.Println()
 
 	fmt.Println("   Notifications:")
 	if cfg.Notifications.Telegram.Enabled {
-		green.Println("      • Telegram: Enabled")
+		green.Println("      • Telegram: Enabled" + deliveryLabel(cfg.Notifications.Telegram.Delivery) + quietLabel(cfg.Notifications.Telegram.QuietHours))
+		for _, c := range cfg.Notifications.Telegram.All()[1:] {
+			green.Printf("         ↳ %s%s%s\n", c.ChannelName(), deliveryLabel(c.Delivery), quietLabel(c.QuietHours))
+		}
 	} else {
 		yellow.Println("      • Telegram: Disabled")
 	}
 	if cfg.Notifications.Email.Enabled {
-		green.Println("      • Email: Enabled")
+		green.Println("      • Email: Enabled" + deliveryLabel(cfg.Notifications.Email.Delivery) + quietLabel(cfg.Notifications.Email.QuietHours))
+		for _, c := range cfg.Notifications.Email.All()[1:] {
+			green.Printf("         ↳ %s%s%s\n", c.ChannelName(), deliveryLabel(c.Delivery), quietLabel(c.QuietHours))
+		}
 	} else {
 		yellow.Println("      • Email: Disabled")
 	}
+	if cfg.Notifications.Slack.Enabled {
+		green.Println("      • Slack: Enabled" + deliveryLabel(cfg.Notifications.Slack.Delivery) + quietLabel(cfg.Notifications.Slack.QuietHours))
+		for _, c := range cfg.Notifications.Slack.All()[1:] {
+			green.Printf("         ↳ %s%s%s\n", c.ChannelName(), deliveryLabel(c.Delivery), quietLabel(c.QuietHours))
+		}
+	} else {
+		yellow.Println("      • Slack: Disabled")
+	}
+	if cfg.Notifications.Discord.Enabled {
+		green.Println("      • Discord: Enabled" + deliveryLabel(cfg.Notifications.Discord.Delivery) + quietLabel(cfg.Notifications.Discord.QuietHours))
+		for _, c := range cfg.Notifications.Discord.All()[1:] {
+			green.Printf("         ↳ %s%s%s\n", c.ChannelName(), deliveryLabel(c.Delivery), quietLabel(c.QuietHours))
+		}
+	} else {
+		yellow.Println("      • Discord: Disabled")
+	}
+	for _, webhook := range cfg.Notifications.Webhooks {
+		signed := ""
+		if webhook.Secret != "" {
+			signed = ", signed"
+		}
+		green.Printf("      • Webhook %s: %s%s%s\n", webhook.Name, webhook.URL, signed, quietLabel(webhook.QuietHours))
+	}
+	if routes := cfg.Notifications.Routes; len(routes) > 0 {
+		fmt.Printf("      • Routes: %d\n", len(routes))
+		for _, route := range routes {
+			fmt.Printf("         ↳ %s → %s\n", routeLabel(route), strings.Join(route.Channels, ", "))
+		}
+	}
 	fmt.Println()
 
 	fmt.Println("   Schedule:")
 	fmt.Printf("      • Interval: %d minutes\n", cfg.Schedule.IntervalMinutes)
 	if cfg.Schedule.QuietHours.Enabled {
-		fmt.Printf("      • Quiet Hours: %s - %s (%s)\n",
+		fmt.Printf("      • Quiet Hours: %s - %s (%s), notifications held until the end\n",
 			cfg.Schedule.QuietHours.Start,
 			cfg.Schedule.QuietHours.End,
 			cfg.Schedule.QuietHours.Timezone)
@@ -96,8 +135,17 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	fmt.Println("   Storage:")
-	fmt.Printf("      • Database: %s\n", cfg.Storage.Database)
-	fmt.Printf("      • Retention: %d days\n", cfg.Storage.RetentionDays)
+	if cfg.Storage.DSN != "" {
+		fmt.Println("      • Database: PostgreSQL (storage.dsn)")
+	} else {
+		fmt.Printf("      • Database: %s\n", cfg.Storage.Database)
+	}
+	retention := cfg.Storage.EffectiveRetention()
+	fmt.Printf("      • Retention: seen %dd, notifications %dd, runs %dd, matches %dd, jobs %dd\n",
+		retention.JobsSeen, retention.Notifications, retention.RunLogs, retention.SearchMatches, retention.Jobs)
+	if backup := cfg.Storage.Backup; backup.Enabled {
+		fmt.Printf("      • Backups: every %d hours to %s (keep %d)\n", backup.IntervalHours, backup.Dir, backup.Keep)
+	}
 	fmt.Println()
 
 	return nil
@@ -143,6 +191,12 @@ func runTestNotify(cmd *cobra.Command, args []string) error {
 			testErr = tn.SendTest()
 		case *notifier.EmailNotifier:
 			testErr = tn.SendTest()
+		case *notifier.SlackNotifier:
+			testErr = tn.SendTest()
+		case *notifier.DiscordNotifier:
+			testErr = tn.SendTest()
+		case *notifier.WebhookNotifier:
+			testErr = tn.SendTest()
 		default:
 			testErr = fmt.Errorf("unknown notifier type")
 		}
@@ -159,3 +213,62 @@ func runTestNotify(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// deliveryLabel describes a channel's digest schedule, empty for instant
+// delivery
+func deliveryLabel(d config.DeliveryConfig) string {
+	if !d.IsDigest() {
+		return ""
+	}
+	label := " (digest every " + d.Every
+	if len(d.At) > 0 {
+		label = " (digest at " + strings.Join(d.At, ", ")
+	}
+	if d.Timezone != "" {
+		label += " " + d.Timezone
+	}
+	return label + ")"
+}
+
+// quietLabel describes a channel's own quiet hours, empty when it follows
+// schedule.quiet_hours
+func quietLabel(q *config.QuietHours) string {
+	switch {
+	case q == nil:
+		return ""
+	case !q.Enabled:
+		return " (no quiet hours)"
+	case q.Timezone != "":
+		return fmt.Sprintf(" (quiet %s - %s %s)", q.Start, q.End, q.Timezone)
+	default:
+		return fmt.Sprintf(" (quiet %s - %s)", q.Start, q.End)
+	}
+}
+
+// routeLabel describes the conditions of a route
+func routeLabel(route config.RouteConfig) string {
+	var conditions []string
+	if len(route.Searches) > 0 {
+		conditions = append(conditions, "searches "+strings.Join(route.Searches, ", "))
+	}
+	if route.MinScore > 0 {
+		conditions = append(conditions, fmt.Sprintf("score ≥ %.2f", route.MinScore))
+	}
+	if route.MinBudget > 0 {
+		conditions = append(conditions, fmt.Sprintf("budget ≥ $%.0f", route.MinBudget))
+	}
+	if route.MaxBudget > 0 {
+		conditions = append(conditions, fmt.Sprintf("budget ≤ $%.0f", route.MaxBudget))
+	}
+	if len(route.Tags) > 0 {
+		conditions = append(conditions, "tags "+strings.Join(route.Tags, ", "))
+	}
+	if len(conditions) == 0 {
+		conditions = append(conditions, "all jobs")
+	}
+	label := strings.Join(conditions, "; ")
+	if route.Final {
+		label += " (final)"
+	}
+	return label
+}
@@ -4,6 +4,7 @@ go 1.21
 
 require (
 	github.com/fatih/color v1.14.1
+	github.com/lib/pq v1.10.9
 	github.com/mattn/go-sqlite3 v1.14.32
 	github.com/mmcdole/gofeed v1.3.0
 	github.com/robfig/cron/v3 v3.0.1
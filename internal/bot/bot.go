@@ -0,0 +1,257 @@
+// Package bot runs the interactive Telegram bot: it long-polls for
+// updates, records the action buttons pressed under job alerts and answers
+// chat commands from the configured chats.
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+	"jobradar/internal/notifier"
+	"jobradar/internal/storage"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Long polling: Telegram holds getUpdates open for pollTimeout when there
+// is nothing to deliver; failed polls are retried after retryWait
+const (
+	pollTimeout = 30 * time.Second
+	retryWait   = 5 * time.Second
+)
+
+// Controller pauses and resumes scheduled checks
+type Controller interface {
+	Pause()
+	Resume()
+	Paused() bool
+}
+
+// Bot answers Telegram updates for the configured chats
+type Bot struct {
+	config   config.TelegramConfig
+	searches []config.SearchConfig
+	store    storage.Store
+	control  Controller
+	client   *http.Client
+	apiURL   string
+	allowed  map[string]bool
+	offset   int64
+}
+
+// New creates a bot for the Telegram channel of cfg
+func New(cfg *config.AppConfig, store storage.Store, control Controller) *Bot {
+	telegram := cfg.Notifications.Telegram
+	allowed := make(map[string]bool)
+	for _, id := range telegram.Bot.AllowedChats {
+		allowed[id] = true
+	}
+	// Alerts sent to instance chats by the same bot carry its buttons too
+	for _, c := range telegram.All() {
+		if c.BotToken == telegram.BotToken {
+			allowed[c.ChatID] = true
+		}
+	}
+
+	return &Bot{
+		config:   telegram,
+		searches: cfg.Searches,
+		store:    store,
+		control:  control,
+		client:   &http.Client{Timeout: pollTimeout + 10*time.Second},
+		apiURL:   notifier.TelegramAPIURL,
+		allowed:  allowed,
+	}
+}
+
+// Run polls for updates and handles them until ctx is cancelled
+func (b *Bot) Run(ctx context.Context) {
+	log.Info().Msg("Telegram bot started")
+	for {
+		updates, err := b.poll(ctx)
+		if ctx.Err() != nil {
+			log.Info().Msg("Telegram bot stopped")
+			return
+		}
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to get Telegram updates")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryWait):
+			}
+			continue
+		}
+
+		for _, u := range updates {
+			b.offset = u.UpdateID + 1
+			b.handle(ctx, u)
+		}
+	}
+}
+
+// Telegram Bot API objects, limited to the fields the bot reads
+type (
+	update struct {
+		UpdateID      int64          `json:"update_id"`
+		Message       *message       `json:"message"`
+		CallbackQuery *callbackQuery `json:"callback_query"`
+	}
+
+	message struct {
+		MessageID int64  `json:"message_id"`
+		Chat      chat   `json:"chat"`
+		Text      string `json:"text"`
+	}
+
+	chat struct {
+		ID int64 `json:"id"`
+	}
+
+	callbackQuery struct {
+		ID      string   `json:"id"`
+		Message *message `json:"message"`
+		Data    string   `json:"data"`
+	}
+)
+
+// poll waits for the next updates after the last one handled
+func (b *Bot) poll(ctx context.Context) ([]update, error) {
+	var updates []update
+	err := b.call(ctx, "getUpdates", map[string]interface{}{
+		"offset":          b.offset,
+		"timeout":         int(pollTimeout.Seconds()),
+		"allowed_updates": []string{"message", "callback_query"},
+	}, &updates)
+	return updates, err
+}
+
+// handle dispatches an update from an allowed chat
+func (b *Bot) handle(ctx context.Context, u update) {
+	switch {
+	case u.CallbackQuery != nil && u.CallbackQuery.Message != nil:
+		if !b.isAllowed(u.CallbackQuery.Message.Chat.ID) {
+			b.answerCallback(ctx, u.CallbackQuery.ID, "Not allowed")
+			return
+		}
+		b.handleAction(ctx, u.CallbackQuery)
+	case u.Message != nil && strings.HasPrefix(u.Message.Text, "/"):
+		if !b.isAllowed(u.Message.Chat.ID) {
+			log.Warn().Int64("chat", u.Message.Chat.ID).Msg("Ignoring command from unknown chat")
+			return
+		}
+		reply := b.command(u.Message.Text)
+		if err := b.send(ctx, u.Message.Chat.ID, reply); err != nil {
+			log.Error().Err(err).Msg("Failed to answer Telegram command")
+		}
+	}
+}
+
+// isAllowed reports whether a chat may use the bot
+func (b *Bot) isAllowed(chatID int64) bool {
+	return b.allowed[strconv.FormatInt(chatID, 10)]
+}
+
+// actionUpdates maps alert buttons to tracking updates
+var actionUpdates = map[string]model.TrackUpdate{
+	notifier.ActionSave:       {Status: model.TrackStatusSaved},
+	notifier.ActionApplied:    {Status: model.TrackStatusApplied},
+	notifier.ActionDismiss:    {Status: model.TrackStatusIgnored},
+	notifier.ActionIrrelevant: {Status: model.TrackStatusIgnored, Note: "Not relevant (Telegram)"},
+}
+
+// handleAction records a button pressed under a job alert and checks it
+func (b *Bot) handleAction(ctx context.Context, cb *callbackQuery) {
+	action, jobID, ok := notifier.ParseJobAction(cb.Data)
+	if !ok {
+		b.answerCallback(ctx, cb.ID, "Unknown action")
+		return
+	}
+
+	u := actionUpdates[action]
+	u.JobRef = jobID
+	tracked, err := b.store.UpdateTrackedJob(u)
+	if err != nil {
+		log.Error().Err(err).Str("job", jobID).Msg("Failed to track job from Telegram")
+		b.answerCallback(ctx, cb.ID, "Failed: "+err.Error())
+		return
+	}
+	log.Info().Str("job", jobID).Str("status", string(tracked.Status)).Msg("Job tracked from Telegram")
+
+	b.answerCallback(ctx, cb.ID, "Marked "+string(tracked.Status))
+	err = b.call(ctx, "editMessageReplyMarkup", map[string]interface{}{
+		"chat_id":      cb.Message.Chat.ID,
+		"message_id":   cb.Message.MessageID,
+		"reply_markup": notifier.JobKeyboard(jobID, action),
+	}, nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to update alert buttons")
+	}
+}
+
+// answerCallback shows a short notice for a pressed button
+func (b *Bot) answerCallback(ctx context.Context, id, text string) {
+	err := b.call(ctx, "answerCallbackQuery", map[string]interface{}{
+		"callback_query_id": id,
+		"text":              text,
+	}, nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to answer Telegram callback")
+	}
+}
+
+// send sends a plain text message
+func (b *Bot) send(ctx context.Context, chatID int64, text string) error {
+	return b.call(ctx, "sendMessage", map[string]interface{}{
+		"chat_id":                  chatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	}, nil)
+}
+
+// call invokes a Bot API method, decoding its result into result if set
+func (b *Bot) call(ctx context.Context, method string, payload, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	url := fmt.Sprintf(b.apiURL, b.config.BotToken, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var reply struct {
+		OK          bool            `json:"ok"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return fmt.Errorf("telegram API returned status %d", resp.StatusCode)
+	}
+	if !reply.OK {
+		return fmt.Errorf("telegram API error: %s", reply.Description)
+	}
+	if result != nil {
+		if err := json.Unmarshal(reply.Result, result); err != nil {
+			return fmt.Errorf("failed to decode %s result: %w", method, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+	"jobradar/internal/storage"
+)
+
+// fakeControl records pause and resume
+type fakeControl struct{ paused bool }
+
+func (c *fakeControl) Pause()       { c.paused = true }
+func (c *fakeControl) Resume()      { c.paused = false }
+func (c *fakeControl) Paused() bool { return c.paused }
+
+// fakeTelegram serves queued updates once and records every other call
+type fakeTelegram struct {
+	mu      sync.Mutex
+	updates []update
+	calls   map[string][]map[string]interface{}
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	var payload map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&payload)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := interface{}(true)
+	if method == "getUpdates" {
+		result, f.updates = f.updates, nil
+		if result.([]update) == nil {
+			result = []update{}
+		}
+	} else {
+		f.calls[method] = append(f.calls[method], payload)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result})
+}
+
+func (f *fakeTelegram) called(method string) []map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+func newTestBot(t *testing.T, updates []update) (*Bot, *fakeTelegram, storage.Store, *fakeControl) {
+	store, err := storage.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	if errors.Is(err, storage.ErrSQLiteCGO) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	api := &fakeTelegram{updates: updates, calls: make(map[string][]map[string]interface{})}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	cfg := &config.AppConfig{
+		Searches: []config.SearchConfig{{Name: "Golang", Keywords: []string{"golang", "go developer"}}},
+		Notifications: config.NotificationConfig{
+			Telegram: config.TelegramConfig{
+				Enabled:  true,
+				BotToken: "token",
+				ChatID:   "100",
+				Bot:      config.TelegramBotConfig{Enabled: true, AllowedChats: []string{"200"}},
+			},
+		},
+	}
+	control := &fakeControl{}
+	b := New(cfg, store, control)
+	b.apiURL = server.URL + "/bot%s/%s"
+	return b, api, store, control
+}
+
+func TestBot_Commands(t *testing.T) {
+	b, _, _, control := newTestBot(t, nil)
+
+	if reply := b.command("/pause"); !strings.Contains(reply, "Paused") || !control.paused {
+		t.Errorf("/pause = %q, paused = %v", reply, control.paused)
+	}
+	if reply := b.command("/stats@jobradar_bot"); !strings.Contains(reply, "Checks: 0") || !strings.Contains(reply, "⏸ Paused") {
+		t.Errorf("/stats = %q", reply)
+	}
+	if reply := b.command("/resume"); !strings.Contains(reply, "Resumed") || control.paused {
+		t.Errorf("/resume = %q, paused = %v", reply, control.paused)
+	}
+	if reply := b.command("/searches"); !strings.Contains(reply, "Golang\n  golang, go developer") {
+		t.Errorf("/searches = %q", reply)
+	}
+	if reply := b.command("/history x"); !strings.HasPrefix(reply, "Usage") {
+		t.Errorf("/history x = %q, want usage", reply)
+	}
+	if reply := b.command("/nope"); !strings.Contains(reply, "/help") {
+		t.Errorf("/nope = %q", reply)
+	}
+}
+
+func TestBot_Run(t *testing.T) {
+	b, api, store, _ := newTestBot(t, []update{
+		{UpdateID: 1, Message: &message{MessageID: 1, Chat: chat{ID: 200}, Text: "/history"}},
+		{UpdateID: 2, Message: &message{MessageID: 2, Chat: chat{ID: 999}, Text: "/pause"}},
+		{UpdateID: 3, CallbackQuery: &callbackQuery{
+			ID:      "cb1",
+			Message: &message{MessageID: 42, Chat: chat{ID: 100}},
+			Data:    "irrelevant:~01a",
+		}},
+	})
+	if err := store.MarkSeen("~01a", "Go API", "https://www.upwork.com/jobs/~01a"); err != nil {
+		t.Fatalf("MarkSeen() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		b.Run(ctx)
+		close(done)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for len(api.called("editMessageReplyMarkup")) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	sent := api.called("sendMessage")
+	if len(sent) != 1 || sent[0]["chat_id"] != float64(200) {
+		t.Fatalf("sendMessage calls = %v, want one reply to the allowed chat", sent)
+	}
+	if !strings.Contains(sent[0]["text"].(string), "No notifications yet") {
+		t.Errorf("history reply = %q", sent[0]["text"])
+	}
+
+	tracked, err := store.GetTrackedJob("~01a")
+	if err != nil || tracked == nil {
+		t.Fatalf("GetTrackedJob() = %v, %v, want the job tracked", tracked, err)
+	}
+	if tracked.Status != model.TrackStatusIgnored || len(tracked.Notes) != 1 {
+		t.Errorf("tracked = %s with %d notes, want ignored with a note", tracked.Status, len(tracked.Notes))
+	}
+
+	answers := api.called("answerCallbackQuery")
+	if len(answers) != 1 || answers[0]["text"] != "Marked ignored" {
+		t.Errorf("answerCallbackQuery calls = %v", answers)
+	}
+	markup, _ := json.Marshal(api.called("editMessageReplyMarkup")[0]["reply_markup"])
+	if !strings.Contains(string(markup), "✅ 👎 Not relevant") {
+		t.Errorf("reply_markup = %s, want the chosen action checked", markup)
+	}
+}
@@ -0,0 +1,146 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"jobradar/internal/model"
+	"jobradar/internal/templates"
+)
+
+// History shown by /history, by default and at most
+const (
+	defaultHistory = 10
+	maxHistory     = 30 // Keeps the reply within Telegram's message limit
+)
+
+const helpText = `🤖 JobRadar commands
+
+/stats - Check and notification totals
+/pause - Skip scheduled checks
+/resume - Resume scheduled checks
+/searches - Configured searches and their matches
+/history [n] - Last n notifications (default 10)
+
+Use the buttons under an alert to save, dismiss or mark a job as applied.`
+
+// command runs a chat command and returns the reply
+func (b *Bot) command(text string) string {
+	fields := strings.Fields(text)
+	// Commands in groups may be addressed as /stats@botname
+	name, _, _ := strings.Cut(fields[0], "@")
+
+	switch strings.ToLower(name) {
+	case "/start", "/help":
+		return helpText
+	case "/stats":
+		return b.stats()
+	case "/pause":
+		if b.control.Paused() {
+			return "⏸ Already paused. Send /resume to start checking again."
+		}
+		b.control.Pause()
+		return "⏸ Paused. Scheduled checks are skipped until /resume."
+	case "/resume":
+		if !b.control.Paused() {
+			return "▶️ Not paused."
+		}
+		b.control.Resume()
+		return "▶️ Resumed. Jobs are checked on the next scheduled run."
+	case "/searches":
+		return b.listSearches()
+	case "/history":
+		limit := defaultHistory
+		if len(fields) > 1 {
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n < 1 {
+				return "Usage: /history [n]"
+			}
+			limit = min(n, maxHistory)
+		}
+		return b.history(limit)
+	default:
+		return "Unknown command. Send /help for the list."
+	}
+}
+
+// stats summarizes runs and notifications
+func (b *Bot) stats() string {
+	stats, err := b.store.GetOverallStats()
+	if err != nil {
+		return "Failed to get stats: " + err.Error()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("📊 JobRadar stats\n\n")
+	fmt.Fprintf(&sb, "Checks: %d\n", stats.TotalRuns)
+	fmt.Fprintf(&sb, "Jobs fetched: %d\n", stats.TotalJobsFetched)
+	fmt.Fprintf(&sb, "Jobs matched: %d\n", stats.TotalJobsMatched)
+	fmt.Fprintf(&sb, "Jobs notified: %d\n", stats.TotalJobsNotified)
+	if stats.LastRunAt != nil {
+		fmt.Fprintf(&sb, "Last check: %s\n", model.TimeAgo(*stats.LastRunAt))
+	}
+	if stats.LastMatchAt != nil {
+		fmt.Fprintf(&sb, "Last match: %s\n", model.TimeAgo(*stats.LastMatchAt))
+	}
+	if b.control.Paused() {
+		sb.WriteString("\n⏸ Paused")
+	} else {
+		sb.WriteString("\n▶️ Running")
+	}
+	return sb.String()
+}
+
+// listSearches lists the configured searches with their match totals
+func (b *Bot) listSearches() string {
+	if len(b.searches) == 0 {
+		return "No searches configured."
+	}
+
+	matches := make(map[string]*model.SearchStats)
+	if stats, err := b.store.GetSearchStats(); err == nil {
+		for _, s := range stats {
+			matches[s.SearchName] = s
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🔎 Searches\n")
+	for _, search := range b.searches {
+		fmt.Fprintf(&sb, "\n%s\n  %s\n", search.Name, strings.Join(search.Keywords, ", "))
+		if s := matches[search.Name]; s != nil && s.TotalMatches > 0 {
+			fmt.Fprintf(&sb, "  %d matches", s.TotalMatches)
+			if s.LastMatchAt != nil {
+				fmt.Fprintf(&sb, ", last %s", model.TimeAgo(*s.LastMatchAt))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// history lists the most recent notifications
+func (b *Bot) history(limit int) string {
+	records, err := b.store.QueryNotifyRecords(model.NotifyQuery{Limit: limit})
+	if err != nil {
+		return "Failed to get history: " + err.Error()
+	}
+	if len(records) == 0 {
+		return "No notifications yet."
+	}
+
+	var sb strings.Builder
+	sb.WriteString("📜 Recent notifications\n")
+	for _, r := range records {
+		icon := "⏳"
+		switch r.Status {
+		case model.NotifyStatusSent:
+			icon = "✅"
+		case model.NotifyStatusFailed:
+			icon = "❌"
+		}
+		fmt.Fprintf(&sb, "\n%s %s · %s\n%s\n", icon, model.TimeAgo(r.CreatedAt), r.NotifyChannel, templates.Truncate(80, r.JobTitle))
+	}
+	return sb.String()
+}
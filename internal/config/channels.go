@@ -0,0 +1,238 @@
+package config
+
+// Channel names identify notification channels in notification records
+// and routes: each channel type is named after itself and its extra
+// instances "<type>:<name>", like webhooks
+func channelName(kind, name string) string {
+	if name == "" {
+		return kind
+	}
+	return kind + ":" + name
+}
+
+// inherit fills an unset instance field from the channel
+func inherit(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// inheritList fills an unset instance list from the channel
+func inheritList(field *[]string, value []string) {
+	if len(*field) == 0 {
+		*field = value
+	}
+}
+
+// inheritDelivery keeps the channel's delivery unless the instance sets a mode
+func inheritDelivery(d *DeliveryConfig, channel DeliveryConfig) {
+	if d.Mode == "" {
+		*d = channel
+	}
+}
+
+// ChannelName returns the channel name, telegram or telegram:<name>
+func (c TelegramConfig) ChannelName() string {
+	return channelName("telegram", c.Name)
+}
+
+// All returns the channel followed by its instances, with the fields an
+// instance leaves unset taken from the channel. Nothing when disabled.
+func (c TelegramConfig) All() []TelegramConfig {
+	if !c.Enabled {
+		return nil
+	}
+	all := []TelegramConfig{c}
+	for _, inst := range c.Instances {
+		inst.Enabled = true
+		inherit(&inst.BotToken, c.BotToken)
+		inherit(&inst.ChatID, c.ChatID)
+		inherit(&inst.Template, c.Template)
+		inheritDelivery(&inst.Delivery, c.Delivery)
+		if inst.QuietHours == nil {
+			inst.QuietHours = c.QuietHours
+		}
+		// Only the channel's own bot answers the alert buttons
+		inst.Bot = TelegramBotConfig{}
+		if inst.BotToken == c.BotToken {
+			inst.Bot = c.Bot
+		}
+		inst.Instances = nil
+		all = append(all, inst)
+	}
+	all[0].Instances = nil
+	return all
+}
+
+// ChannelName returns the channel name, email or email:<name>
+func (c EmailConfig) ChannelName() string {
+	return channelName("email", c.Name)
+}
+
+// All returns the channel followed by its instances, with the fields an
+// instance leaves unset taken from the channel. Nothing when disabled.
+func (c EmailConfig) All() []EmailConfig {
+	if !c.Enabled {
+		return nil
+	}
+	all := []EmailConfig{c}
+	for _, inst := range c.Instances {
+		inst.Enabled = true
+		inherit(&inst.SMTPHost, c.SMTPHost)
+		if inst.SMTPPort == 0 {
+			inst.SMTPPort = c.SMTPPort
+		}
+		inherit(&inst.Security, c.Security)
+		inherit(&inst.Auth, c.Auth)
+		inherit(&inst.Username, c.Username)
+		inherit(&inst.Password, c.Password)
+		inherit(&inst.From, c.From)
+		inherit(&inst.FromName, c.FromName)
+		inheritList(&inst.To, c.To)
+		inheritList(&inst.Cc, c.Cc)
+		inheritList(&inst.Bcc, c.Bcc)
+		inherit(&inst.Subject, c.Subject)
+		inherit(&inst.Template, c.Template)
+		inherit(&inst.TextTemplate, c.TextTemplate)
+		inheritDelivery(&inst.Delivery, c.Delivery)
+		if inst.QuietHours == nil {
+			inst.QuietHours = c.QuietHours
+		}
+		inst.Instances = nil
+		all = append(all, inst)
+	}
+	all[0].Instances = nil
+	return all
+}
+
+// ChannelName returns the channel name, slack or slack:<name>
+func (c SlackConfig) ChannelName() string {
+	return channelName("slack", c.Name)
+}
+
+// All returns the channel followed by its instances, with the fields an
+// instance leaves unset taken from the channel. Nothing when disabled.
+func (c SlackConfig) All() []SlackConfig {
+	if !c.Enabled {
+		return nil
+	}
+	all := []SlackConfig{c}
+	for _, inst := range c.Instances {
+		inst.Enabled = true
+		inherit(&inst.WebhookURL, c.WebhookURL)
+		inherit(&inst.Channel, c.Channel)
+		inheritDelivery(&inst.Delivery, c.Delivery)
+		if inst.QuietHours == nil {
+			inst.QuietHours = c.QuietHours
+		}
+		inst.Instances = nil
+		all = append(all, inst)
+	}
+	all[0].Instances = nil
+	return all
+}
+
+// ChannelName returns the channel name, discord or discord:<name>
+func (c DiscordConfig) ChannelName() string {
+	return channelName("discord", c.Name)
+}
+
+// All returns the channel followed by its instances, with the fields an
+// instance leaves unset taken from the channel. Nothing when disabled.
+func (c DiscordConfig) All() []DiscordConfig {
+	if !c.Enabled {
+		return nil
+	}
+	all := []DiscordConfig{c}
+	for _, inst := range c.Instances {
+		inst.Enabled = true
+		inherit(&inst.WebhookURL, c.WebhookURL)
+		inherit(&inst.Username, c.Username)
+		inheritDelivery(&inst.Delivery, c.Delivery)
+		if inst.QuietHours == nil {
+			inst.QuietHours = c.QuietHours
+		}
+		inst.Instances = nil
+		all = append(all, inst)
+	}
+	all[0].Instances = nil
+	return all
+}
+
+// ChannelName returns the channel name, webhook:<name>
+func (c WebhookConfig) ChannelName() string {
+	return channelName("webhook", c.Name)
+}
+
+// ChannelNames returns the names of all enabled channels
+func (n NotificationConfig) ChannelNames() []string {
+	var names []string
+	for _, c := range n.Telegram.All() {
+		names = append(names, c.ChannelName())
+	}
+	for _, c := range n.Email.All() {
+		names = append(names, c.ChannelName())
+	}
+	for _, c := range n.Slack.All() {
+		names = append(names, c.ChannelName())
+	}
+	for _, c := range n.Discord.All() {
+		names = append(names, c.ChannelName())
+	}
+	for _, c := range n.Webhooks {
+		names = append(names, c.ChannelName())
+	}
+	return names
+}
+
+// Deliveries returns the delivery settings of every enabled channel that
+// has them, keyed by channel name. Webhooks always deliver instantly.
+func (n NotificationConfig) Deliveries() map[string]DeliveryConfig {
+	deliveries := make(map[string]DeliveryConfig)
+	for _, c := range n.Telegram.All() {
+		deliveries[c.ChannelName()] = c.Delivery
+	}
+	for _, c := range n.Email.All() {
+		deliveries[c.ChannelName()] = c.Delivery
+	}
+	for _, c := range n.Slack.All() {
+		deliveries[c.ChannelName()] = c.Delivery
+	}
+	for _, c := range n.Discord.All() {
+		deliveries[c.ChannelName()] = c.Delivery
+	}
+	return deliveries
+}
+
+// ChannelQuietHours returns the quiet hours of every enabled channel, keyed
+// by channel name: its own when set, schedule.quiet_hours otherwise.
+// Channels without quiet hours are left out.
+func (c *AppConfig) ChannelQuietHours() map[string]QuietHours {
+	quiet := make(map[string]QuietHours)
+	add := func(name string, q *QuietHours) {
+		if q == nil {
+			q = &c.Schedule.QuietHours
+		}
+		if q.Enabled {
+			quiet[name] = *q
+		}
+	}
+
+	n := c.Notifications
+	for _, ch := range n.Telegram.All() {
+		add(ch.ChannelName(), ch.QuietHours)
+	}
+	for _, ch := range n.Email.All() {
+		add(ch.ChannelName(), ch.QuietHours)
+	}
+	for _, ch := range n.Slack.All() {
+		add(ch.ChannelName(), ch.QuietHours)
+	}
+	for _, ch := range n.Discord.All() {
+		add(ch.ChannelName(), ch.QuietHours)
+	}
+	for _, ch := range n.Webhooks {
+		add(ch.ChannelName(), ch.QuietHours)
+	}
+	return quiet
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTelegramConfig_All(t *testing.T) {
+	quiet := &QuietHours{Enabled: true, Start: "22:00", End: "07:00"}
+	own := &QuietHours{Enabled: true, Start: "23:00", End: "06:00"}
+	c := TelegramConfig{
+		Enabled:    true,
+		BotToken:   "main-token",
+		ChatID:     "100",
+		Template:   "telegram.tmpl",
+		Delivery:   DeliveryConfig{Mode: DeliveryDigest, Every: "4h"},
+		QuietHours: quiet,
+		Bot:        TelegramBotConfig{Enabled: true},
+		Instances: []TelegramConfig{
+			{Name: "team", ChatID: "200"},
+			{Name: "other", BotToken: "other-token", Delivery: DeliveryConfig{Mode: DeliveryInstant}, QuietHours: own},
+		},
+	}
+
+	all := c.All()
+	if len(all) != 3 {
+		t.Fatalf("All() returned %d channels, want 3", len(all))
+	}
+
+	tests := []struct {
+		name     string
+		got      TelegramConfig
+		channel  string
+		token    string
+		chatID   string
+		mode     string
+		quiet    *QuietHours
+		botReply bool
+	}{
+		{"channel", all[0], "telegram", "main-token", "100", DeliveryDigest, quiet, true},
+		{"inherits unset fields", all[1], "telegram:team", "main-token", "200", DeliveryDigest, quiet, true},
+		{"keeps own fields", all[2], "telegram:other", "other-token", "100", DeliveryInstant, own, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.ChannelName(); got != tt.channel {
+				t.Errorf("ChannelName() = %q, want %q", got, tt.channel)
+			}
+			if tt.got.BotToken != tt.token || tt.got.ChatID != tt.chatID {
+				t.Errorf("bot token, chat = %q, %q, want %q, %q", tt.got.BotToken, tt.got.ChatID, tt.token, tt.chatID)
+			}
+			if tt.got.Template != "telegram.tmpl" {
+				t.Errorf("template = %q, want the channel's", tt.got.Template)
+			}
+			if tt.got.Delivery.Mode != tt.mode {
+				t.Errorf("delivery mode = %q, want %q", tt.got.Delivery.Mode, tt.mode)
+			}
+			if tt.got.QuietHours != tt.quiet {
+				t.Errorf("quiet hours = %+v, want %+v", tt.got.QuietHours, tt.quiet)
+			}
+			if tt.got.Bot.Enabled != tt.botReply {
+				t.Errorf("bot enabled = %v, want %v", tt.got.Bot.Enabled, tt.botReply)
+			}
+			if !tt.got.Enabled || len(tt.got.Instances) != 0 {
+				t.Errorf("enabled = %v with %d instances, want enabled without instances", tt.got.Enabled, len(tt.got.Instances))
+			}
+		})
+	}
+
+	c.Enabled = false
+	if got := c.All(); got != nil {
+		t.Errorf("All() of a disabled channel = %v, want nil", got)
+	}
+}
+
+func TestEmailConfig_All(t *testing.T) {
+	c := EmailConfig{
+		Enabled:  true,
+		SMTPHost: "smtp.example.com",
+		SMTPPort: 587,
+		Username: "bot@example.com",
+		Password: "secret",
+		To:       []string{"me@example.com"},
+		Cc:       []string{"team@example.com"},
+		Subject:  "New job",
+		Instances: []EmailConfig{
+			{Name: "boss", To: []string{"boss@example.com"}},
+			{Name: "relay", SMTPHost: "relay.example.com", SMTPPort: 25},
+		},
+	}
+
+	all := c.All()
+	if len(all) != 3 {
+		t.Fatalf("All() returned %d channels, want 3", len(all))
+	}
+
+	tests := []struct {
+		name    string
+		got     EmailConfig
+		channel string
+		host    string
+		port    int
+		to      string
+	}{
+		{"channel", all[0], "email", "smtp.example.com", 587, "me@example.com"},
+		{"own recipients", all[1], "email:boss", "smtp.example.com", 587, "boss@example.com"},
+		{"own server", all[2], "email:relay", "relay.example.com", 25, "me@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.ChannelName(); got != tt.channel {
+				t.Errorf("ChannelName() = %q, want %q", got, tt.channel)
+			}
+			if tt.got.SMTPHost != tt.host || tt.got.SMTPPort != tt.port {
+				t.Errorf("server = %s:%d, want %s:%d", tt.got.SMTPHost, tt.got.SMTPPort, tt.host, tt.port)
+			}
+			if got := strings.Join(tt.got.To, ","); got != tt.to {
+				t.Errorf("to = %q, want %q", got, tt.to)
+			}
+			if strings.Join(tt.got.Cc, ",") != "team@example.com" || tt.got.Username != "bot@example.com" || tt.got.Subject != "New job" {
+				t.Errorf("cc, username, subject = %v, %q, %q, want the channel's", tt.got.Cc, tt.got.Username, tt.got.Subject)
+			}
+		})
+	}
+}
+
+func TestNotificationConfig_ChannelNames(t *testing.T) {
+	n := NotificationConfig{
+		Telegram: TelegramConfig{Enabled: true, Instances: []TelegramConfig{{Name: "team"}}},
+		Email:    EmailConfig{Instances: []EmailConfig{{Name: "boss"}}},
+		Slack:    SlackConfig{Enabled: true, WebhookURL: "https://hooks.slack.com/a", Instances: []SlackConfig{{Name: "ops"}}},
+		Discord:  DiscordConfig{Enabled: true},
+		Webhooks: []WebhookConfig{{Name: "n8n"}},
+	}
+
+	want := "telegram,telegram:team,slack,slack:ops,discord,webhook:n8n"
+	if got := strings.Join(n.ChannelNames(), ","); got != want {
+		t.Errorf("ChannelNames() = %q, want %q", got, want)
+	}
+	if got := n.Slack.All()[1].WebhookURL; got != "https://hooks.slack.com/a" {
+		t.Errorf("slack instance webhook_url = %q, want the channel's", got)
+	}
+}
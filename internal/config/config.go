@@ -42,32 +42,189 @@ type FilterConfig struct {
 	PostedWithinHours int          `yaml:"posted_within_hours" mapstructure:"posted_within_hours"`
 	MaxProposals      *int         `yaml:"max_proposals,omitempty" mapstructure:"max_proposals"`
 	ExcludeKeywords   []string     `yaml:"exclude_keywords" mapstructure:"exclude_keywords"`
+	Languages         []string     `yaml:"languages,omitempty" mapstructure:"languages"` // ISO 639-1 allow list, empty allows all
 }
 
 // TelegramConfig represents Telegram notification settings
 type TelegramConfig struct {
-	Enabled  bool   `yaml:"enabled" mapstructure:"enabled"`
-	BotToken string `yaml:"bot_token" mapstructure:"bot_token"`
-	ChatID   string `yaml:"chat_id" mapstructure:"chat_id"`
+	Enabled    bool              `yaml:"enabled" mapstructure:"enabled"`
+	BotToken   string            `yaml:"bot_token" mapstructure:"bot_token"`
+	ChatID     string            `yaml:"chat_id" mapstructure:"chat_id"`
+	Template   string            `yaml:"template,omitempty" mapstructure:"template"` // MarkdownV2 text/template file, built-in when empty
+	Delivery   DeliveryConfig    `yaml:"delivery" mapstructure:"delivery"`
+	QuietHours *QuietHours       `yaml:"quiet_hours,omitempty" mapstructure:"quiet_hours"` // Overrides schedule.quiet_hours
+	Bot        TelegramBotConfig `yaml:"bot" mapstructure:"bot"`
+
+	Name      string           `yaml:"name,omitempty" mapstructure:"name"`           // Set on instances only
+	Instances []TelegramConfig `yaml:"instances,omitempty" mapstructure:"instances"` // More chats, named telegram:<name>
+}
+
+// TelegramBotConfig represents the interactive bot run by `jobradar run`:
+// action buttons under alerts and chat commands
+type TelegramBotConfig struct {
+	Enabled      bool     `yaml:"enabled" mapstructure:"enabled"`
+	AllowedChats []string `yaml:"allowed_chats,omitempty" mapstructure:"allowed_chats"` // Chat IDs the bot answers besides chat_id
 }
 
 // EmailConfig represents email notification settings
 type EmailConfig struct {
-	Enabled  bool   `yaml:"enabled" mapstructure:"enabled"`
-	SMTPHost string `yaml:"smtp_host" mapstructure:"smtp_host"`
-	SMTPPort int    `yaml:"smtp_port" mapstructure:"smtp_port"`
-	Username string `yaml:"username" mapstructure:"username"`
-	Password string `yaml:"password" mapstructure:"password"`
-	To       string `yaml:"to" mapstructure:"to"`
+	Enabled      bool           `yaml:"enabled" mapstructure:"enabled"`
+	SMTPHost     string         `yaml:"smtp_host" mapstructure:"smtp_host"`
+	SMTPPort     int            `yaml:"smtp_port" mapstructure:"smtp_port"`
+	Security     string         `yaml:"security,omitempty" mapstructure:"security"` // starttls, tls or none; tls on port 465, starttls otherwise
+	Auth         string         `yaml:"auth,omitempty" mapstructure:"auth"`         // plain, login or none; plain when username is set
+	Username     string         `yaml:"username" mapstructure:"username"`
+	Password     string         `yaml:"password" mapstructure:"password"`
+	From         string         `yaml:"from,omitempty" mapstructure:"from"`           // Sender address, username when empty
+	FromName     string         `yaml:"from_name,omitempty" mapstructure:"from_name"` // Sender display name
+	To           []string       `yaml:"to" mapstructure:"to"`
+	Cc           []string       `yaml:"cc,omitempty" mapstructure:"cc"`
+	Bcc          []string       `yaml:"bcc,omitempty" mapstructure:"bcc"`
+	Subject      string         `yaml:"subject,omitempty" mapstructure:"subject"`             // Inline text/template, built-in when empty
+	Template     string         `yaml:"template,omitempty" mapstructure:"template"`           // html/template body file, built-in when empty
+	TextTemplate string         `yaml:"text_template,omitempty" mapstructure:"text_template"` // text/template plain text body file, built-in when empty
+	Delivery     DeliveryConfig `yaml:"delivery" mapstructure:"delivery"`
+	QuietHours   *QuietHours    `yaml:"quiet_hours,omitempty" mapstructure:"quiet_hours"` // Overrides schedule.quiet_hours
+
+	Name      string        `yaml:"name,omitempty" mapstructure:"name"`           // Set on instances only
+	Instances []EmailConfig `yaml:"instances,omitempty" mapstructure:"instances"` // More recipients, named email:<name>
+}
+
+// Email connection security and authentication modes
+const (
+	EmailSecurityStartTLS = "starttls"
+	EmailSecurityTLS      = "tls"
+	EmailSecurityNone     = "none"
+
+	EmailAuthPlain = "plain"
+	EmailAuthLogin = "login"
+	EmailAuthNone  = "none"
+)
+
+// SecurityMode returns the connection security, defaulting by port
+func (c EmailConfig) SecurityMode() string {
+	switch {
+	case c.Security != "":
+		return c.Security
+	case c.SMTPPort == 465:
+		return EmailSecurityTLS
+	}
+	return EmailSecurityStartTLS
+}
+
+// AuthMode returns the authentication mode, defaulting by whether a
+// username is set
+func (c EmailConfig) AuthMode() string {
+	switch {
+	case c.Auth != "":
+		return c.Auth
+	case c.Username != "":
+		return EmailAuthPlain
+	}
+	return EmailAuthNone
+}
+
+// Sender returns the sender address
+func (c EmailConfig) Sender() string {
+	if c.From != "" {
+		return c.From
+	}
+	return c.Username
+}
+
+// SlackConfig represents Slack incoming webhook settings
+type SlackConfig struct {
+	Enabled    bool           `yaml:"enabled" mapstructure:"enabled"`
+	WebhookURL string         `yaml:"webhook_url" mapstructure:"webhook_url"`
+	Channel    string         `yaml:"channel,omitempty" mapstructure:"channel"` // Overrides the webhook's default channel
+	Delivery   DeliveryConfig `yaml:"delivery" mapstructure:"delivery"`
+	QuietHours *QuietHours    `yaml:"quiet_hours,omitempty" mapstructure:"quiet_hours"` // Overrides schedule.quiet_hours
+
+	Name      string        `yaml:"name,omitempty" mapstructure:"name"`           // Set on instances only
+	Instances []SlackConfig `yaml:"instances,omitempty" mapstructure:"instances"` // More channels, named slack:<name>
+}
+
+// DiscordConfig represents Discord webhook settings
+type DiscordConfig struct {
+	Enabled    bool           `yaml:"enabled" mapstructure:"enabled"`
+	WebhookURL string         `yaml:"webhook_url" mapstructure:"webhook_url"`
+	Username   string         `yaml:"username,omitempty" mapstructure:"username"` // Overrides the webhook's bot name
+	Delivery   DeliveryConfig `yaml:"delivery" mapstructure:"delivery"`
+	QuietHours *QuietHours    `yaml:"quiet_hours,omitempty" mapstructure:"quiet_hours"` // Overrides schedule.quiet_hours
+
+	Name      string          `yaml:"name,omitempty" mapstructure:"name"`           // Set on instances only
+	Instances []DiscordConfig `yaml:"instances,omitempty" mapstructure:"instances"` // More webhooks, named discord:<name>
+}
+
+// Delivery modes
+const (
+	DeliveryInstant = "instant"
+	DeliveryDigest  = "digest"
+)
+
+// DeliveryConfig represents when a channel sends notifications: each job
+// as it is found, or matches batched into a digest every interval or at
+// fixed times of day
+type DeliveryConfig struct {
+	Mode     string   `yaml:"mode" mapstructure:"mode"`                   // instant (default) or digest
+	Every    string   `yaml:"every,omitempty" mapstructure:"every"`       // Digest interval, e.g. "30m" or "4h"
+	At       []string `yaml:"at,omitempty" mapstructure:"at"`             // Digest times, e.g. ["09:00", "17:30"]
+	Timezone string   `yaml:"timezone,omitempty" mapstructure:"timezone"` // For every and at, UTC when empty
+}
+
+// IsDigest reports whether the channel batches notifications into digests
+func (d DeliveryConfig) IsDigest() bool {
+	return d.Mode == DeliveryDigest
+}
+
+// WebhookConfig represents a generic HTTP webhook. The body is the job as
+// JSON unless a Go text/template body is given.
+type WebhookConfig struct {
+	Name           string            `yaml:"name" mapstructure:"name"`
+	URL            string            `yaml:"url" mapstructure:"url"`
+	Headers        map[string]string `yaml:"headers,omitempty" mapstructure:"headers"`
+	BodyTemplate   string            `yaml:"body_template,omitempty" mapstructure:"body_template"`
+	Secret         string            `yaml:"secret,omitempty" mapstructure:"secret"`               // Signs requests with HMAC-SHA256
+	SuccessCodes   []int             `yaml:"success_codes,omitempty" mapstructure:"success_codes"` // Any 2xx when empty
+	TimeoutSeconds int               `yaml:"timeout_seconds,omitempty" mapstructure:"timeout_seconds"`
+	QuietHours     *QuietHours       `yaml:"quiet_hours,omitempty" mapstructure:"quiet_hours"` // Overrides schedule.quiet_hours
 }
 
 // NotificationConfig represents all notification channels
 type NotificationConfig struct {
-	Telegram TelegramConfig `yaml:"telegram" mapstructure:"telegram"`
-	Email    EmailConfig    `yaml:"email" mapstructure:"email"`
+	Telegram TelegramConfig  `yaml:"telegram" mapstructure:"telegram"`
+	Email    EmailConfig     `yaml:"email" mapstructure:"email"`
+	Slack    SlackConfig     `yaml:"slack" mapstructure:"slack"`
+	Discord  DiscordConfig   `yaml:"discord" mapstructure:"discord"`
+	Webhooks []WebhookConfig `yaml:"webhooks" mapstructure:"webhooks"`
+	Routes   []RouteConfig   `yaml:"routes,omitempty" mapstructure:"routes"` // Every channel gets every job when empty
+	Retry    RetryConfig     `yaml:"retry" mapstructure:"retry"`
+}
+
+// RouteConfig sends the jobs matching all of its conditions to channels.
+// A route without conditions matches every job. Routes are checked in
+// order and a job goes to the channels of every matching route, up to the
+// first matching route marked final.
+type RouteConfig struct {
+	Searches  []string `yaml:"searches,omitempty" mapstructure:"searches"`     // Any of these searches matched the job
+	MinScore  float64  `yaml:"min_score,omitempty" mapstructure:"min_score"`   // Best match score, 0 to 1
+	MinBudget float64  `yaml:"min_budget,omitempty" mapstructure:"min_budget"` // Fixed budget or hourly rate
+	MaxBudget float64  `yaml:"max_budget,omitempty" mapstructure:"max_budget"`
+	Tags      []string `yaml:"tags,omitempty" mapstructure:"tags"` // Any of these skill tags, case-insensitive
+	Channels  []string `yaml:"channels" mapstructure:"channels"`   // Channel names, e.g. telegram, email:boss, webhook:n8n
+	Final     bool     `yaml:"final,omitempty" mapstructure:"final"`
 }
 
-// QuietHours represents the quiet period configuration
+// RetryConfig represents how failed notifications are retried on later
+// check cycles. The delay doubles after each failed attempt.
+type RetryConfig struct {
+	MaxAttempts    int `yaml:"max_attempts" mapstructure:"max_attempts"`
+	BackoffMinutes int `yaml:"backoff_minutes" mapstructure:"backoff_minutes"` // Delay before the first retry
+	ExpireHours    int `yaml:"expire_hours" mapstructure:"expire_hours"`       // Give up on notifications older than this
+}
+
+// QuietHours represents a daily quiet period. Checks keep running, but
+// notifications are held and sent together when it ends.
 type QuietHours struct {
 	Enabled  bool   `yaml:"enabled" mapstructure:"enabled"`
 	Start    string `yaml:"start" mapstructure:"start"`
@@ -81,10 +238,43 @@ type ScheduleConfig struct {
 	QuietHours      QuietHours `yaml:"quiet_hours" mapstructure:"quiet_hours"`
 }
 
+// BackupConfig represents scheduled SQLite backups taken by the daemon
+type BackupConfig struct {
+	Enabled       bool   `yaml:"enabled" mapstructure:"enabled"`
+	Dir           string `yaml:"dir" mapstructure:"dir"`
+	IntervalHours int    `yaml:"interval_hours" mapstructure:"interval_hours"`
+	Keep          int    `yaml:"keep" mapstructure:"keep"` // Number of backups to keep
+}
+
+// RetentionConfig represents how many days each table keeps records.
+// Zero uses storage.retention_days.
+type RetentionConfig struct {
+	JobsSeen      int `yaml:"jobs_seen" mapstructure:"jobs_seen"`
+	Notifications int `yaml:"notifications" mapstructure:"notifications"`
+	RunLogs       int `yaml:"run_logs" mapstructure:"run_logs"` // Rolled up into daily totals before deletion
+	SearchMatches int `yaml:"search_matches" mapstructure:"search_matches"`
+	Jobs          int `yaml:"jobs" mapstructure:"jobs"`
+}
+
 // StorageConfig represents storage settings
 type StorageConfig struct {
-	Database      string `yaml:"database" mapstructure:"database"`
-	RetentionDays int    `yaml:"retention_days" mapstructure:"retention_days"`
+	Database      string          `yaml:"database" mapstructure:"database"`
+	DSN           string          `yaml:"dsn,omitempty" mapstructure:"dsn"` // PostgreSQL DSN, overrides database when set
+	RetentionDays int             `yaml:"retention_days" mapstructure:"retention_days"`
+	Retention     RetentionConfig `yaml:"retention" mapstructure:"retention"`
+	Backup        BackupConfig    `yaml:"backup" mapstructure:"backup"`
+}
+
+// EffectiveRetention returns the retention of every table, filling unset
+// values from RetentionDays
+func (c StorageConfig) EffectiveRetention() RetentionConfig {
+	r := c.Retention
+	for _, days := range []*int{&r.JobsSeen, &r.Notifications, &r.RunLogs, &r.SearchMatches, &r.Jobs} {
+		if *days == 0 {
+			*days = c.RetentionDays
+		}
+	}
+	return r
 }
 
 // AppConfig represents the complete application configuration
@@ -110,6 +300,14 @@ func DefaultConfig() *AppConfig {
 			PostedWithinHours: 24,
 			MaxProposals:      &maxProposals,
 			ExcludeKeywords:   []string{},
+			Languages:         []string{},
+		},
+		Notifications: NotificationConfig{
+			Retry: RetryConfig{
+				MaxAttempts:    5,
+				BackoffMinutes: 5,
+				ExpireHours:    24,
+			},
 		},
 		Schedule: ScheduleConfig{
 			IntervalMinutes: 30,
@@ -121,6 +319,12 @@ func DefaultConfig() *AppConfig {
 		Storage: StorageConfig{
 			Database:      "jobradar.db",
 			RetentionDays: 7,
+			Backup: BackupConfig{
+				Enabled:       false,
+				Dir:           "backups",
+				IntervalHours: 24,
+				Keep:          7,
+			},
 		},
 	}
 }
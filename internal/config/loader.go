@@ -2,9 +2,15 @@ package config
 
 import (
 	"fmt"
+	"net/mail"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
+
+	"jobradar/internal/langdetect"
+	"jobradar/internal/templates"
 
 	"github.com/spf13/viper"
 )
@@ -44,10 +50,58 @@ func expandEnvVars(cfg *AppConfig) {
 	// Telegram config
 	cfg.Notifications.Telegram.BotToken = expandEnvVar(cfg.Notifications.Telegram.BotToken)
 	cfg.Notifications.Telegram.ChatID = expandEnvVar(cfg.Notifications.Telegram.ChatID)
+	for i, id := range cfg.Notifications.Telegram.Bot.AllowedChats {
+		cfg.Notifications.Telegram.Bot.AllowedChats[i] = expandEnvVar(id)
+	}
+	for i := range cfg.Notifications.Telegram.Instances {
+		inst := &cfg.Notifications.Telegram.Instances[i]
+		inst.BotToken = expandEnvVar(inst.BotToken)
+		inst.ChatID = expandEnvVar(inst.ChatID)
+	}
 
 	// Email config
 	cfg.Notifications.Email.Username = expandEnvVar(cfg.Notifications.Email.Username)
 	cfg.Notifications.Email.Password = expandEnvVar(cfg.Notifications.Email.Password)
+	cfg.Notifications.Email.From = expandEnvVar(cfg.Notifications.Email.From)
+	expandEnvList(cfg.Notifications.Email.To)
+	expandEnvList(cfg.Notifications.Email.Cc)
+	expandEnvList(cfg.Notifications.Email.Bcc)
+	for i := range cfg.Notifications.Email.Instances {
+		inst := &cfg.Notifications.Email.Instances[i]
+		inst.Username = expandEnvVar(inst.Username)
+		inst.Password = expandEnvVar(inst.Password)
+		inst.From = expandEnvVar(inst.From)
+		expandEnvList(inst.To)
+		expandEnvList(inst.Cc)
+		expandEnvList(inst.Bcc)
+	}
+
+	// Slack config
+	cfg.Notifications.Slack.WebhookURL = expandEnvVar(cfg.Notifications.Slack.WebhookURL)
+	for i := range cfg.Notifications.Slack.Instances {
+		inst := &cfg.Notifications.Slack.Instances[i]
+		inst.WebhookURL = expandEnvVar(inst.WebhookURL)
+	}
+
+	// Discord config
+	cfg.Notifications.Discord.WebhookURL = expandEnvVar(cfg.Notifications.Discord.WebhookURL)
+	for i := range cfg.Notifications.Discord.Instances {
+		inst := &cfg.Notifications.Discord.Instances[i]
+		inst.WebhookURL = expandEnvVar(inst.WebhookURL)
+	}
+
+	// Webhooks
+	for i := range cfg.Notifications.Webhooks {
+		webhook := &cfg.Notifications.Webhooks[i]
+		webhook.URL = expandEnvVar(webhook.URL)
+		webhook.Secret = expandEnvVar(webhook.Secret)
+		for k, v := range webhook.Headers {
+			webhook.Headers[k] = expandEnvVar(v)
+		}
+	}
+
+	// Storage config
+	cfg.Storage.DSN = expandEnvVar(cfg.Storage.DSN)
 }
 
 // expandEnvVar expands a single ${VAR} pattern
@@ -62,6 +116,14 @@ func expandEnvVar(s string) string {
 	})
 }
 
+// expandEnvList expands ${VAR} patterns in every item of a list, trimming
+// the spaces left by comma separated values
+func expandEnvList(list []string) {
+	for i, s := range list {
+		list[i] = strings.TrimSpace(expandEnvVar(s))
+	}
+}
+
 // validate checks if the configuration is valid
 func validate(cfg *AppConfig) error {
 	var errors []string
@@ -122,8 +184,18 @@ func validate(cfg *AppConfig) error {
 		errors = append(errors, fmt.Sprintf("invalid job_type: %s (must be fixed, hourly, or all)", cfg.Filters.JobType))
 	}
 
+	// Validate languages
+	for _, lang := range cfg.Filters.Languages {
+		if !langdetect.IsSupported(lang) {
+			errors = append(errors, fmt.Sprintf("filters.languages: unsupported language code %q (supported: %s)",
+				lang, strings.Join(langdetect.Supported(), ", ")))
+		}
+	}
+
 	// Validate notifications - at least one should be enabled
-	if !cfg.Notifications.Telegram.Enabled && !cfg.Notifications.Email.Enabled {
+	if !cfg.Notifications.Telegram.Enabled && !cfg.Notifications.Email.Enabled &&
+		!cfg.Notifications.Slack.Enabled && !cfg.Notifications.Discord.Enabled &&
+		len(cfg.Notifications.Webhooks) == 0 {
 		errors = append(errors, "at least one notification channel must be enabled")
 	}
 
@@ -135,6 +207,20 @@ func validate(cfg *AppConfig) error {
 		if cfg.Notifications.Telegram.ChatID == "" || strings.HasPrefix(cfg.Notifications.Telegram.ChatID, "${") {
 			errors = append(errors, "telegram.chat_id is required when telegram is enabled")
 		}
+		for _, id := range cfg.Notifications.Telegram.Bot.AllowedChats {
+			if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+				errors = append(errors, fmt.Sprintf("telegram.bot.allowed_chats: %q is not a numeric chat ID", id))
+			}
+		}
+		if path := cfg.Notifications.Telegram.Template; path != "" {
+			if _, err := templates.Telegram(path); err != nil {
+				errors = append(errors, fmt.Sprintf("telegram.template: %v", err))
+			}
+		}
+	}
+
+	if cfg.Notifications.Telegram.Bot.Enabled && !cfg.Notifications.Telegram.Enabled {
+		errors = append(errors, "telegram.bot requires telegram to be enabled")
 	}
 
 	// Validate Email config if enabled
@@ -142,23 +228,166 @@ func validate(cfg *AppConfig) error {
 		if cfg.Notifications.Email.SMTPHost == "" {
 			errors = append(errors, "email.smtp_host is required when email is enabled")
 		}
-		if cfg.Notifications.Email.To == "" {
+		if len(cfg.Notifications.Email.To) == 0 {
 			errors = append(errors, "email.to is required when email is enabled")
 		}
+		errors = append(errors, validateEmail("email", cfg.Notifications.Email)...)
+		if subject := cfg.Notifications.Email.Subject; subject != "" {
+			if _, err := templates.EmailSubject(subject); err != nil {
+				errors = append(errors, fmt.Sprintf("email.subject: %v", err))
+			}
+		}
+		if path := cfg.Notifications.Email.Template; path != "" {
+			if _, err := templates.EmailBody(path); err != nil {
+				errors = append(errors, fmt.Sprintf("email.template: %v", err))
+			}
+		}
+		if path := cfg.Notifications.Email.TextTemplate; path != "" {
+			if _, err := templates.EmailText(path); err != nil {
+				errors = append(errors, fmt.Sprintf("email.text_template: %v", err))
+			}
+		}
+	}
+
+	// Validate Slack config if enabled
+	if cfg.Notifications.Slack.Enabled {
+		url := cfg.Notifications.Slack.WebhookURL
+		if url == "" || strings.HasPrefix(url, "${") {
+			errors = append(errors, "slack.webhook_url is required when slack is enabled")
+		} else if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+			errors = append(errors, "slack.webhook_url must be an http(s) URL")
+		}
+	}
+
+	// Validate Discord config if enabled
+	if cfg.Notifications.Discord.Enabled {
+		url := cfg.Notifications.Discord.WebhookURL
+		if url == "" || strings.HasPrefix(url, "${") {
+			errors = append(errors, "discord.webhook_url is required when discord is enabled")
+		} else if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+			errors = append(errors, "discord.webhook_url must be an http(s) URL")
+		}
+	}
+
+	// Validate delivery modes
+	for _, d := range []struct {
+		name     string
+		enabled  bool
+		delivery DeliveryConfig
+	}{
+		{"telegram", cfg.Notifications.Telegram.Enabled, cfg.Notifications.Telegram.Delivery},
+		{"email", cfg.Notifications.Email.Enabled, cfg.Notifications.Email.Delivery},
+		{"slack", cfg.Notifications.Slack.Enabled, cfg.Notifications.Slack.Delivery},
+		{"discord", cfg.Notifications.Discord.Enabled, cfg.Notifications.Discord.Delivery},
+	} {
+		if d.enabled {
+			errors = append(errors, validateDelivery(d.name+".delivery", d.delivery)...)
+		}
+	}
+
+	// Validate channel instances
+	errors = append(errors, validateInstances(cfg.Notifications)...)
+
+	// Validate webhooks
+	webhookNames := make(map[string]bool)
+	for i, webhook := range cfg.Notifications.Webhooks {
+		if webhook.Name == "" {
+			errors = append(errors, fmt.Sprintf("webhooks[%d]: name is required", i))
+		} else if webhookNames[webhook.Name] {
+			errors = append(errors, fmt.Sprintf("webhooks[%d]: duplicate name %q", i, webhook.Name))
+		}
+		webhookNames[webhook.Name] = true
+
+		if webhook.URL == "" || strings.Contains(webhook.URL, "${") {
+			errors = append(errors, fmt.Sprintf("webhooks[%d]: url is required", i))
+		} else if !strings.HasPrefix(webhook.URL, "https://") && !strings.HasPrefix(webhook.URL, "http://") {
+			errors = append(errors, fmt.Sprintf("webhooks[%d]: url must be an http(s) URL", i))
+		}
+		if strings.HasPrefix(webhook.Secret, "${") {
+			errors = append(errors, fmt.Sprintf("webhooks[%d]: secret environment variable is not set", i))
+		}
+		for _, code := range webhook.SuccessCodes {
+			if code < 100 || code > 599 {
+				errors = append(errors, fmt.Sprintf("webhooks[%d]: invalid success code %d", i, code))
+			}
+		}
+		if webhook.TimeoutSeconds < 0 {
+			errors = append(errors, fmt.Sprintf("webhooks[%d]: timeout_seconds must not be negative", i))
+		}
+	}
+
+	// Validate routes
+	errors = append(errors, validateRoutes(cfg)...)
+
+	// Validate notification retries
+	retry := cfg.Notifications.Retry
+	if retry.MaxAttempts < 1 {
+		errors = append(errors, "notifications.retry.max_attempts must be at least 1")
+	}
+	if retry.BackoffMinutes < 1 {
+		errors = append(errors, "notifications.retry.backoff_minutes must be at least 1")
+	}
+	if retry.ExpireHours < 1 {
+		errors = append(errors, "notifications.retry.expire_hours must be at least 1")
 	}
 
 	// Validate schedule
 	if cfg.Schedule.IntervalMinutes < 1 {
 		errors = append(errors, "schedule.interval_minutes must be at least 1")
 	}
+	errors = append(errors, validateQuietHours("schedule.quiet_hours", &cfg.Schedule.QuietHours)...)
+	errors = append(errors, validateQuietHours("telegram.quiet_hours", cfg.Notifications.Telegram.QuietHours)...)
+	errors = append(errors, validateQuietHours("email.quiet_hours", cfg.Notifications.Email.QuietHours)...)
+	errors = append(errors, validateQuietHours("slack.quiet_hours", cfg.Notifications.Slack.QuietHours)...)
+	errors = append(errors, validateQuietHours("discord.quiet_hours", cfg.Notifications.Discord.QuietHours)...)
+	for i, webhook := range cfg.Notifications.Webhooks {
+		errors = append(errors, validateQuietHours(fmt.Sprintf("webhooks[%d].quiet_hours", i), webhook.QuietHours)...)
+	}
 
 	// Validate storage
-	if cfg.Storage.Database == "" {
+	if cfg.Storage.DSN != "" {
+		if strings.Contains(cfg.Storage.DSN, "${") {
+			errors = append(errors, "storage.dsn contains an unset environment variable")
+		} else if !strings.HasPrefix(cfg.Storage.DSN, "postgres://") &&
+			!strings.HasPrefix(cfg.Storage.DSN, "postgresql://") &&
+			!strings.Contains(cfg.Storage.DSN, "=") {
+			errors = append(errors, "storage.dsn must be a PostgreSQL URL (postgres://...) or key=value connection string")
+		}
+	} else if cfg.Storage.Database == "" {
 		errors = append(errors, "storage.database is required")
 	}
 	if cfg.Storage.RetentionDays < 1 {
 		errors = append(errors, "storage.retention_days must be at least 1")
 	}
+	retention := cfg.Storage.Retention
+	for _, r := range []struct {
+		name string
+		days int
+	}{
+		{"jobs_seen", retention.JobsSeen},
+		{"notifications", retention.Notifications},
+		{"run_logs", retention.RunLogs},
+		{"search_matches", retention.SearchMatches},
+		{"jobs", retention.Jobs},
+	} {
+		if r.days < 0 {
+			errors = append(errors, fmt.Sprintf("storage.retention.%s must not be negative", r.name))
+		}
+	}
+	if backup := cfg.Storage.Backup; backup.Enabled {
+		if cfg.Storage.DSN != "" {
+			errors = append(errors, "storage.backup is only supported for SQLite; use pg_dump for PostgreSQL")
+		}
+		if backup.Dir == "" {
+			errors = append(errors, "storage.backup.dir is required when backups are enabled")
+		}
+		if backup.IntervalHours < 1 {
+			errors = append(errors, "storage.backup.interval_hours must be at least 1")
+		}
+		if backup.Keep < 1 {
+			errors = append(errors, "storage.backup.keep must be at least 1")
+		}
+	}
 
 	if len(errors) > 0 {
 		return fmt.Errorf("configuration errors:\n  - %s", strings.Join(errors, "\n  - "))
@@ -167,6 +396,246 @@ func validate(cfg *AppConfig) error {
 	return nil
 }
 
+// validateDelivery checks a channel's delivery mode and digest schedule
+func validateDelivery(name string, d DeliveryConfig) []string {
+	var errors []string
+	switch d.Mode {
+	case "", DeliveryInstant:
+		if d.Every != "" || len(d.At) > 0 {
+			errors = append(errors, fmt.Sprintf("%s: every and at only apply to mode digest", name))
+		}
+		return errors
+	case DeliveryDigest:
+	default:
+		return []string{fmt.Sprintf("%s.mode: invalid mode %q (must be instant or digest)", name, d.Mode)}
+	}
+
+	if (d.Every == "") == (len(d.At) == 0) {
+		errors = append(errors, fmt.Sprintf("%s: digest needs either every or at", name))
+	}
+	if d.Every != "" {
+		if every, err := time.ParseDuration(d.Every); err != nil {
+			errors = append(errors, fmt.Sprintf("%s.every: invalid duration %q (e.g. 30m or 4h)", name, d.Every))
+		} else if every < time.Minute {
+			errors = append(errors, fmt.Sprintf("%s.every must be at least 1m", name))
+		}
+	}
+	for _, at := range d.At {
+		if _, err := time.Parse("15:04", at); err != nil {
+			errors = append(errors, fmt.Sprintf("%s.at: invalid time %q (must be HH:MM)", name, at))
+		}
+	}
+	if d.Timezone != "" {
+		if _, err := time.LoadLocation(d.Timezone); err != nil {
+			errors = append(errors, fmt.Sprintf("%s.timezone: unknown timezone %q", name, d.Timezone))
+		}
+	}
+	return errors
+}
+
+// validateInstances checks the extra instances of each channel type. Fields
+// they inherit were checked with the channel.
+func validateInstances(n NotificationConfig) []string {
+	var errors []string
+	names := func(kind string, enabled bool, count int, name func(i int) string) {
+		if count > 0 && !enabled {
+			errors = append(errors, fmt.Sprintf("%s.instances require %s to be enabled", kind, kind))
+		}
+		seen := make(map[string]bool)
+		for i := 0; i < count; i++ {
+			switch name := name(i); {
+			case name == "":
+				errors = append(errors, fmt.Sprintf("%s.instances[%d]: name is required", kind, i))
+			case strings.ContainsAny(name, ": "):
+				errors = append(errors, fmt.Sprintf("%s.instances[%d]: name %q must not contain spaces or colons", kind, i, name))
+			case seen[name]:
+				errors = append(errors, fmt.Sprintf("%s.instances[%d]: duplicate name %q", kind, i, name))
+			default:
+				seen[name] = true
+			}
+		}
+	}
+	delivery := func(kind string, i int, d DeliveryConfig, q *QuietHours) {
+		if d.Mode != "" {
+			errors = append(errors, validateDelivery(fmt.Sprintf("%s.instances[%d].delivery", kind, i), d)...)
+		}
+		errors = append(errors, validateQuietHours(fmt.Sprintf("%s.instances[%d].quiet_hours", kind, i), q)...)
+	}
+	webhookURL := func(kind string, i int, url string) {
+		if strings.HasPrefix(url, "${") {
+			errors = append(errors, fmt.Sprintf("%s.instances[%d]: webhook_url environment variable is not set", kind, i))
+		} else if url != "" && !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+			errors = append(errors, fmt.Sprintf("%s.instances[%d]: webhook_url must be an http(s) URL", kind, i))
+		}
+	}
+
+	telegram := n.Telegram.Instances
+	names("telegram", n.Telegram.Enabled, len(telegram), func(i int) string { return telegram[i].Name })
+	for i, inst := range telegram {
+		if strings.HasPrefix(inst.BotToken, "${") || strings.HasPrefix(inst.ChatID, "${") {
+			errors = append(errors, fmt.Sprintf("telegram.instances[%d]: environment variable is not set", i))
+		}
+		if inst.Template != "" {
+			if _, err := templates.Telegram(inst.Template); err != nil {
+				errors = append(errors, fmt.Sprintf("telegram.instances[%d].template: %v", i, err))
+			}
+		}
+		delivery("telegram", i, inst.Delivery, inst.QuietHours)
+	}
+
+	email := n.Email.Instances
+	names("email", n.Email.Enabled, len(email), func(i int) string { return email[i].Name })
+	for i, inst := range email {
+		if inst.Subject != "" {
+			if _, err := templates.EmailSubject(inst.Subject); err != nil {
+				errors = append(errors, fmt.Sprintf("email.instances[%d].subject: %v", i, err))
+			}
+		}
+		if inst.Template != "" {
+			if _, err := templates.EmailBody(inst.Template); err != nil {
+				errors = append(errors, fmt.Sprintf("email.instances[%d].template: %v", i, err))
+			}
+		}
+		if inst.TextTemplate != "" {
+			if _, err := templates.EmailText(inst.TextTemplate); err != nil {
+				errors = append(errors, fmt.Sprintf("email.instances[%d].text_template: %v", i, err))
+			}
+		}
+		if n.Email.Enabled {
+			errors = append(errors, validateEmail(fmt.Sprintf("email.instances[%d]", i), n.Email.All()[i+1])...)
+		}
+		delivery("email", i, inst.Delivery, inst.QuietHours)
+	}
+
+	slack := n.Slack.Instances
+	names("slack", n.Slack.Enabled, len(slack), func(i int) string { return slack[i].Name })
+	for i, inst := range slack {
+		webhookURL("slack", i, inst.WebhookURL)
+		delivery("slack", i, inst.Delivery, inst.QuietHours)
+	}
+
+	discord := n.Discord.Instances
+	names("discord", n.Discord.Enabled, len(discord), func(i int) string { return discord[i].Name })
+	for i, inst := range discord {
+		webhookURL("discord", i, inst.WebhookURL)
+		delivery("discord", i, inst.Delivery, inst.QuietHours)
+	}
+	return errors
+}
+
+// validateEmail checks an email channel's connection modes and addresses
+func validateEmail(name string, c EmailConfig) []string {
+	var errors []string
+	switch c.SecurityMode() {
+	case EmailSecurityStartTLS, EmailSecurityTLS, EmailSecurityNone:
+	default:
+		errors = append(errors, fmt.Sprintf("%s.security: invalid mode %q (must be starttls, tls or none)", name, c.Security))
+	}
+	switch c.AuthMode() {
+	case EmailAuthNone:
+	case EmailAuthPlain, EmailAuthLogin:
+		if c.Username == "" || strings.HasPrefix(c.Username, "${") || strings.HasPrefix(c.Password, "${") {
+			errors = append(errors, fmt.Sprintf("%s: username and password are required for auth %s", name, c.AuthMode()))
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("%s.auth: invalid mode %q (must be plain, login or none)", name, c.Auth))
+	}
+
+	if c.Sender() == "" {
+		errors = append(errors, fmt.Sprintf("%s.from is required when username is not set", name))
+	} else if _, err := mail.ParseAddress(c.Sender()); err != nil {
+		field := "from"
+		if c.From == "" {
+			field = "username" // Doubles as the sender
+		}
+		errors = append(errors, fmt.Sprintf("%s.%s: invalid sender address %q, set from", name, field, c.Sender()))
+	}
+	for _, list := range []struct {
+		field     string
+		addresses []string
+	}{
+		{"to", c.To},
+		{"cc", c.Cc},
+		{"bcc", c.Bcc},
+	} {
+		for _, address := range list.addresses {
+			if _, err := mail.ParseAddress(address); err != nil {
+				errors = append(errors, fmt.Sprintf("%s.%s: invalid address %q", name, list.field, address))
+			}
+		}
+	}
+	return errors
+}
+
+// validateRoutes checks that routes refer to configured searches, have
+// sane conditions and send to enabled channels
+func validateRoutes(cfg *AppConfig) []string {
+	var errors []string
+	channels := make(map[string]bool)
+	for _, name := range cfg.Notifications.ChannelNames() {
+		channels[name] = true
+	}
+	// RSS feeds match jobs under their own name
+	searches := make(map[string]bool)
+	for _, search := range cfg.Searches {
+		searches[search.Name] = true
+	}
+	for _, feed := range cfg.RSSFeeds {
+		searches[feed.Name] = true
+	}
+
+	for i, route := range cfg.Notifications.Routes {
+		for _, search := range route.Searches {
+			if !searches[search] {
+				errors = append(errors, fmt.Sprintf("notifications.routes[%d]: unknown search %q", i, search))
+			}
+		}
+		if len(route.Channels) == 0 {
+			errors = append(errors, fmt.Sprintf("notifications.routes[%d]: at least one channel is required", i))
+		}
+		for _, channel := range route.Channels {
+			if !channels[channel] {
+				errors = append(errors, fmt.Sprintf("notifications.routes[%d]: unknown or disabled channel %q", i, channel))
+			}
+		}
+		if route.MinScore < 0 || route.MinScore > 1 {
+			errors = append(errors, fmt.Sprintf("notifications.routes[%d].min_score must be between 0 and 1", i))
+		}
+		if route.MinBudget < 0 || route.MaxBudget < 0 {
+			errors = append(errors, fmt.Sprintf("notifications.routes[%d]: budgets cannot be negative", i))
+		}
+		if route.MaxBudget > 0 && route.MaxBudget < route.MinBudget {
+			errors = append(errors, fmt.Sprintf("notifications.routes[%d].max_budget must be >= min_budget", i))
+		}
+	}
+	return errors
+}
+
+// validateQuietHours checks an enabled quiet period, if set
+func validateQuietHours(name string, q *QuietHours) []string {
+	if q == nil || !q.Enabled {
+		return nil
+	}
+	var errors []string
+	start, startErr := time.Parse("15:04", q.Start)
+	if startErr != nil {
+		errors = append(errors, fmt.Sprintf("%s.start: invalid time %q (must be HH:MM)", name, q.Start))
+	}
+	end, endErr := time.Parse("15:04", q.End)
+	if endErr != nil {
+		errors = append(errors, fmt.Sprintf("%s.end: invalid time %q (must be HH:MM)", name, q.End))
+	}
+	if startErr == nil && endErr == nil && start.Equal(end) {
+		errors = append(errors, fmt.Sprintf("%s: start and end must differ", name))
+	}
+	if q.Timezone != "" {
+		if _, err := time.LoadLocation(q.Timezone); err != nil {
+			errors = append(errors, fmt.Sprintf("%s.timezone: unknown timezone %q", name, q.Timezone))
+		}
+	}
+	return errors
+}
+
 // ValidateOnly validates the configuration without returning it
 func ValidateOnly() error {
 	_, err := Load()
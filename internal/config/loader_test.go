@@ -0,0 +1,130 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateInstances(t *testing.T) {
+	tests := []struct {
+		name string
+		n    NotificationConfig
+		want string // Expected error, empty when valid
+	}{
+		{
+			name: "valid",
+			n: NotificationConfig{
+				Telegram: TelegramConfig{Enabled: true, Instances: []TelegramConfig{{Name: "team", ChatID: "200"}}},
+				Slack:    SlackConfig{Enabled: true, Instances: []SlackConfig{{Name: "ops", WebhookURL: "https://hooks.slack.com/b"}}},
+			},
+		},
+		{
+			name: "channel disabled",
+			n:    NotificationConfig{Slack: SlackConfig{Instances: []SlackConfig{{Name: "ops"}}}},
+			want: "slack.instances require slack to be enabled",
+		},
+		{
+			name: "missing name",
+			n:    NotificationConfig{Discord: DiscordConfig{Enabled: true, Instances: []DiscordConfig{{}}}},
+			want: "discord.instances[0]: name is required",
+		},
+		{
+			name: "name with colon",
+			n:    NotificationConfig{Telegram: TelegramConfig{Enabled: true, Instances: []TelegramConfig{{Name: "a:b"}}}},
+			want: `telegram.instances[0]: name "a:b" must not contain spaces or colons`,
+		},
+		{
+			name: "duplicate name",
+			n:    NotificationConfig{Slack: SlackConfig{Enabled: true, Instances: []SlackConfig{{Name: "ops"}, {Name: "ops"}}}},
+			want: `slack.instances[1]: duplicate name "ops"`,
+		},
+		{
+			name: "unset environment variable",
+			n:    NotificationConfig{Telegram: TelegramConfig{Enabled: true, Instances: []TelegramConfig{{Name: "team", ChatID: "${TEAM_CHAT}"}}}},
+			want: "telegram.instances[0]: environment variable is not set",
+		},
+		{
+			name: "webhook url not http",
+			n:    NotificationConfig{Discord: DiscordConfig{Enabled: true, Instances: []DiscordConfig{{Name: "alerts", WebhookURL: "ftp://example.com"}}}},
+			want: "discord.instances[0]: webhook_url must be an http(s) URL",
+		},
+		{
+			name: "invalid delivery",
+			n:    NotificationConfig{Slack: SlackConfig{Enabled: true, Instances: []SlackConfig{{Name: "ops", Delivery: DeliveryConfig{Mode: "hourly"}}}}},
+			want: `slack.instances[0].delivery.mode: invalid mode "hourly"`,
+		},
+		{
+			name: "invalid inherited email sender",
+			n:    NotificationConfig{Email: EmailConfig{Enabled: true, From: "not an address", Instances: []EmailConfig{{Name: "boss"}}}},
+			want: `email.instances[0].from: invalid sender address "not an address"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkErrors(t, validateInstances(tt.n), tt.want)
+		})
+	}
+}
+
+func TestValidateRoutes(t *testing.T) {
+	base := AppConfig{
+		Searches: []SearchConfig{{Name: "Golang"}},
+		RSSFeeds: []RSSFeedConfig{{Name: "Go feed"}},
+		Notifications: NotificationConfig{
+			Telegram: TelegramConfig{Enabled: true, Instances: []TelegramConfig{{Name: "team"}}},
+			Email:    EmailConfig{Instances: []EmailConfig{{Name: "boss"}}},
+			Webhooks: []WebhookConfig{{Name: "n8n"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		route RouteConfig
+		want  string // Expected error, empty when valid
+	}{
+		{"valid", RouteConfig{Searches: []string{"Golang"}, MinScore: 0.5, MinBudget: 100, MaxBudget: 500, Channels: []string{"telegram:team"}}, ""},
+		{"rss feed as search", RouteConfig{Searches: []string{"Go feed"}, Channels: []string{"webhook:n8n"}}, ""},
+		{"open budget range", RouteConfig{MinBudget: 100, Channels: []string{"telegram"}}, ""},
+		{"unknown search", RouteConfig{Searches: []string{"Rust"}, Channels: []string{"telegram"}}, `notifications.routes[0]: unknown search "Rust"`},
+		{"no channels", RouteConfig{}, "notifications.routes[0]: at least one channel is required"},
+		{"unknown channel", RouteConfig{Channels: []string{"telegram:ops"}}, `notifications.routes[0]: unknown or disabled channel "telegram:ops"`},
+		{"disabled channel", RouteConfig{Channels: []string{"email:boss"}}, `notifications.routes[0]: unknown or disabled channel "email:boss"`},
+		{"min score above 1", RouteConfig{MinScore: 1.5, Channels: []string{"telegram"}}, "notifications.routes[0].min_score must be between 0 and 1"},
+		{"negative min score", RouteConfig{MinScore: -0.1, Channels: []string{"telegram"}}, "notifications.routes[0].min_score must be between 0 and 1"},
+		{"negative budget", RouteConfig{MinBudget: -1, Channels: []string{"telegram"}}, "notifications.routes[0]: budgets cannot be negative"},
+		{"reversed budget range", RouteConfig{MinBudget: 500, MaxBudget: 100, Channels: []string{"telegram"}}, "notifications.routes[0].max_budget must be >= min_budget"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			cfg.Notifications.Routes = []RouteConfig{tt.route}
+			checkErrors(t, validateRoutes(&cfg), tt.want)
+		})
+	}
+}
+
+func TestValidate_ReversedBudget(t *testing.T) {
+	cfg := &AppConfig{Filters: FilterConfig{Budget: BudgetFilter{Min: 500, Max: 100}}}
+	err := validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "filters.budget.max must be >= min") {
+		t.Errorf("validate() error = %v, want the reversed budget reported", err)
+	}
+}
+
+// checkErrors fails the test unless errors is empty when want is, or
+// otherwise has an error starting with want
+func checkErrors(t *testing.T, errors []string, want string) {
+	t.Helper()
+	if want == "" {
+		if len(errors) > 0 {
+			t.Errorf("errors = %q, want none", errors)
+		}
+		return
+	}
+	for _, e := range errors {
+		if strings.HasPrefix(e, want) {
+			return
+		}
+	}
+	t.Errorf("errors = %q, want %q", errors, want)
+}
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+	"jobradar/internal/notifier"
+
+	"github.com/rs/zerolog/log"
+)
+
+// digestSchedule is when a channel in digest mode sends its digests:
+// every interval counted from midnight, or at fixed times of day
+type digestSchedule struct {
+	every    time.Duration
+	at       []time.Duration // Offsets from midnight, ascending
+	location *time.Location
+}
+
+// newDigestSchedule parses a channel's digest settings
+func newDigestSchedule(cfg config.DeliveryConfig) (*digestSchedule, error) {
+	d := &digestSchedule{location: time.UTC}
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone: %w", err)
+		}
+		d.location = loc
+	}
+
+	if cfg.Every != "" {
+		every, err := time.ParseDuration(cfg.Every)
+		if err != nil || every < time.Minute {
+			return nil, fmt.Errorf("invalid digest interval %q", cfg.Every)
+		}
+		d.every = every
+		return d, nil
+	}
+
+	for _, at := range cfg.At {
+		t, err := time.Parse("15:04", at)
+		if err != nil {
+			return nil, fmt.Errorf("invalid digest time %q", at)
+		}
+		d.at = append(d.at, time.Duration(t.Hour())*time.Hour+time.Duration(t.Minute())*time.Minute)
+	}
+	if len(d.at) == 0 {
+		return nil, fmt.Errorf("digest needs either every or at")
+	}
+	sort.Slice(d.at, func(i, j int) bool { return d.at[i] < d.at[j] })
+	return d, nil
+}
+
+// digestSchedules returns the schedule of every enabled channel in digest
+// mode, keyed by channel name
+func digestSchedules(cfg config.NotificationConfig) (map[string]*digestSchedule, error) {
+	deliveries := cfg.Deliveries()
+	names := make([]string, 0, len(deliveries))
+	for name := range deliveries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	schedules := make(map[string]*digestSchedule)
+	for _, name := range names {
+		if !deliveries[name].IsDigest() {
+			continue
+		}
+		d, err := newDigestSchedule(deliveries[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s delivery: %w", name, err)
+		}
+		schedules[name] = d
+	}
+	return schedules, nil
+}
+
+// next returns the first digest time after now
+func (d *digestSchedule) next(now time.Time) time.Time {
+	local := now.In(d.location)
+	day := func(offset int) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, d.location)
+	}
+
+	if d.every > 0 {
+		midnight := day(0)
+		next := midnight.Add((local.Sub(midnight)/d.every + 1) * d.every)
+		// Intervals restart at midnight rather than drifting across days
+		if tomorrow := day(1); next.After(tomorrow) {
+			return tomorrow
+		}
+		return next
+	}
+
+	for offset := 0; ; offset++ {
+		midnight := day(offset)
+		for _, at := range d.at {
+			t := time.Date(midnight.Year(), midnight.Month(), midnight.Day(),
+				int(at/time.Hour), int(at%time.Hour/time.Minute), 0, 0, d.location)
+			if t.After(now) {
+				return t
+			}
+		}
+	}
+}
+
+// sendDigest sends the due notifications of a channel in digest mode as
+// one digest, best scoring first, and records the outcome of each
+func (e *Engine) sendDigest(records []*model.NotifyRecord, n notifier.DigestNotifier, now time.Time) {
+	var jobs []*model.MatchedJob
+	var batch []*model.NotifyRecord
+	for _, r := range records {
+		if r.Job == nil {
+			e.attempt(r, n, now) // Fails for want of a job
+			continue
+		}
+		batch = append(batch, r)
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	sort.SliceStable(batch, func(i, j int) bool {
+		return batch[i].Job.MatchScore > batch[j].Job.MatchScore
+	})
+	for _, r := range batch {
+		jobs = append(jobs, r.Job)
+	}
+
+	delivered, err := n.SendDigest(jobs)
+	if delivered > 0 {
+		log.Info().Str("channel", n.Name()).Int("jobs", delivered).Msg("Digest sent")
+	}
+	for i, r := range batch {
+		if i < delivered {
+			e.settle(r, nil, false, now)
+		} else {
+			e.settle(r, err, false, now)
+		}
+	}
+}
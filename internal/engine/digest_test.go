@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+	"jobradar/internal/notifier"
+	"jobradar/internal/storage"
+)
+
+// digestNotifier records the digests it is sent
+type digestNotifier struct {
+	flakyNotifier
+	digests [][]string
+}
+
+func (n *digestNotifier) SendDigest(jobs []*model.MatchedJob) (int, error) {
+	var ids []string
+	for _, m := range jobs {
+		ids = append(ids, m.Job.ID)
+	}
+	n.digests = append(n.digests, ids)
+	return len(jobs), nil
+}
+
+func TestDigestSchedule_Next(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+
+	tests := []struct {
+		name     string
+		delivery config.DeliveryConfig
+		now      time.Time
+		want     time.Time
+	}{
+		{
+			name:     "every aligned to the clock",
+			delivery: config.DeliveryConfig{Mode: "digest", Every: "2h"},
+			now:      time.Date(2024, 3, 1, 9, 15, 0, 0, time.UTC),
+			want:     time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "every restarts at midnight",
+			delivery: config.DeliveryConfig{Mode: "digest", Every: "5h"},
+			now:      time.Date(2024, 3, 1, 21, 0, 0, 0, time.UTC),
+			want:     time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "next time today",
+			delivery: config.DeliveryConfig{Mode: "digest", At: []string{"18:00", "09:00"}, Timezone: "Europe/Berlin"},
+			now:      time.Date(2024, 3, 1, 9, 0, 0, 0, berlin),
+			want:     time.Date(2024, 3, 1, 18, 0, 0, 0, berlin),
+		},
+		{
+			name:     "first time tomorrow",
+			delivery: config.DeliveryConfig{Mode: "digest", At: []string{"18:00", "09:00"}, Timezone: "Europe/Berlin"},
+			now:      time.Date(2024, 3, 1, 19, 0, 0, 0, berlin),
+			want:     time.Date(2024, 3, 2, 9, 0, 0, 0, berlin),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := newDigestSchedule(tt.delivery)
+			if err != nil {
+				t.Fatalf("newDigestSchedule() error = %v", err)
+			}
+			if got := d.next(tt.now); !got.Equal(tt.want) {
+				t.Errorf("next(%s) = %s, want %s", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngine_DeliverDigest(t *testing.T) {
+	store, err := storage.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	defer store.Close()
+
+	telegram := &flakyNotifier{name: "telegram"}
+	email := &digestNotifier{flakyNotifier: flakyNotifier{name: "email"}}
+	cfg := &config.AppConfig{
+		Notifications: config.NotificationConfig{
+			Email: config.EmailConfig{Enabled: true, Delivery: config.DeliveryConfig{Mode: "digest", Every: "1h"}},
+			Retry: config.RetryConfig{MaxAttempts: 3, BackoffMinutes: 5, ExpireHours: 24},
+		},
+	}
+	digests, err := digestSchedules(cfg.Notifications)
+	if err != nil {
+		t.Fatalf("digestSchedules() error = %v", err)
+	}
+	e := &Engine{config: cfg, storage: store, notifiers: []notifier.Notifier{telegram, email}, digests: digests}
+
+	now := time.Date(2024, 3, 1, 9, 10, 0, 0, time.UTC)
+	for i, score := range []float64{0.4, 0.9, 0.6} {
+		matched := model.NewMatchedJob(&model.Job{ID: fmt.Sprintf("~0%d", i)}, []string{"golang"}, "Golang", 1)
+		matched.MatchScore = score
+		if _, err := e.enqueue(matched, now.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("enqueue() error = %v", err)
+		}
+	}
+
+	e.deliver(now.Add(5 * time.Minute))
+	if len(telegram.sent) != 3 {
+		t.Errorf("telegram sent %v, want every job instantly", telegram.sent)
+	}
+	if len(email.digests) != 0 || len(email.sent) != 0 {
+		t.Fatalf("email sent before the digest was due: %v", email.digests)
+	}
+
+	e.deliver(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	if len(email.digests) != 1 {
+		t.Fatalf("email digests = %v, want one", email.digests)
+	}
+	if got := fmt.Sprint(email.digests[0]); got != "[~01 ~02 ~00]" {
+		t.Errorf("digest = %s, want jobs by score", got)
+	}
+	if len(email.sent) != 0 {
+		t.Errorf("email sent %v one by one", email.sent)
+	}
+
+	pending, err := store.DueNotifications(now.Add(24*time.Hour), 0)
+	if err != nil {
+		t.Fatalf("DueNotifications() error = %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("%d notifications still pending", len(pending))
+	}
+}
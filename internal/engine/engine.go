@@ -1,10 +1,15 @@
 package engine
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"jobradar/internal/bot"
 	"jobradar/internal/config"
 	"jobradar/internal/fetcher"
 	"jobradar/internal/filter"
@@ -19,33 +24,101 @@ import (
 // Engine is the main JobRadar engine that coordinates all components
 type Engine struct {
 	config     *config.AppConfig
-	storage    *storage.Storage
+	storage    storage.Store
 	rssFetcher *fetcher.RSSFetcher
 	apiFetcher *fetcher.UpworkAPIFetcher
 	filter     *filter.Filter
 	notifiers  []notifier.Notifier
+	digests    map[string]*digestSchedule // Channels in digest mode
+	quiet      map[string]*quietHours     // Channels with quiet hours
 	scheduler  *scheduler.Scheduler
+	paused     atomic.Bool // Set from the Telegram bot to skip scheduled checks
+	stopBot    context.CancelFunc
 }
 
 // New creates a new Engine instance
 func New(cfg *config.AppConfig) (*Engine, error) {
 	// Initialize storage
-	store, err := storage.New(cfg.Storage.Database)
+	store, err := storage.New(cfg.Storage)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init storage: %w", err)
 	}
+	return newEngine(cfg, store)
+}
+
+// NewReadOnly creates an Engine for DryRun and Replay, which only read
+// storage. The database is opened without migrating it, so a schema that
+// does not match this release is an error rather than being upgraded.
+func NewReadOnly(cfg *config.AppConfig) (*Engine, error) {
+	store, err := storage.Open(cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+
+	version, err := store.SchemaVersion()
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	switch latest := store.LatestVersion(); {
+	case version < latest:
+		store.Close()
+		return nil, fmt.Errorf("database schema version %d is behind latest %d; run 'jobradar db migrate' first", version, latest)
+	case version > latest:
+		store.Close()
+		return nil, fmt.Errorf("database schema version %d is newer than this release supports (%d)", version, latest)
+	}
+
+	return newEngine(cfg, store)
+}
 
+// newEngine creates an Engine around an open store, closing the store if
+// the rest of the config cannot be set up
+func newEngine(cfg *config.AppConfig, store storage.Store) (*Engine, error) {
 	// Initialize notifiers
 	notifiers := make([]notifier.Notifier, 0)
-	if cfg.Notifications.Telegram.Enabled {
-		n := notifier.NewTelegram(cfg.Notifications.Telegram)
+	for _, c := range cfg.Notifications.Telegram.All() {
+		n, err := notifier.NewTelegram(c)
+		if err != nil {
+			store.Close()
+			return nil, err
+		}
 		notifiers = append(notifiers, n)
 	}
-	if cfg.Notifications.Email.Enabled {
-		n := notifier.NewEmail(cfg.Notifications.Email)
+	for _, c := range cfg.Notifications.Email.All() {
+		n, err := notifier.NewEmail(c)
+		if err != nil {
+			store.Close()
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+	for _, c := range cfg.Notifications.Slack.All() {
+		notifiers = append(notifiers, notifier.NewSlack(c))
+	}
+	for _, c := range cfg.Notifications.Discord.All() {
+		notifiers = append(notifiers, notifier.NewDiscord(c))
+	}
+	for _, webhook := range cfg.Notifications.Webhooks {
+		n, err := notifier.NewWebhook(webhook)
+		if err != nil {
+			store.Close()
+			return nil, err
+		}
 		notifiers = append(notifiers, n)
 	}
 
+	digests, err := digestSchedules(cfg.Notifications)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	quiet, err := channelQuietHours(cfg)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
 	// Initialize fetchers
 	var apiFetcher *fetcher.UpworkAPIFetcher
 	if cfg.UpworkAPI.Enabled {
@@ -59,6 +132,8 @@ func New(cfg *config.AppConfig) (*Engine, error) {
 		apiFetcher: apiFetcher,
 		filter:     filter.New(cfg.Filters),
 		notifiers:  notifiers,
+		digests:    digests,
+		quiet:      quiet,
 	}, nil
 }
 
@@ -66,9 +141,131 @@ func New(cfg *config.AppConfig) (*Engine, error) {
 func (e *Engine) Run() (*model.RunStats, error) {
 	stats := model.NewRunStats()
 
+	// 1. Fetch jobs from configured sources
+	allJobs, feedNames := e.fetch(stats)
+
+	stats.JobsFetched = len(allJobs)
+	log.Info().Int("total", stats.JobsFetched).Msg("Total jobs fetched")
+
+	var runErrors []string
+	if msg := sourceErrors(stats); msg != "" {
+		runErrors = append(runErrors, msg)
+	}
+
+	// 2. Filter and match jobs
+	records, matchedJobs := e.match(allJobs, feedNames, nil)
+
+	// Persist every fetched job with its match result
+	if err := e.storage.SaveJobs(records); err != nil {
+		log.Error().Err(err).Msg("Failed to save jobs")
+		runErrors = append(runErrors, err.Error())
+	}
+
+	stats.JobsMatched = len(matchedJobs)
+	log.Info().Int("matched", stats.JobsMatched).Msg("Jobs matched")
+
+	// 3. Filter out already seen jobs
+	var newJobs []*model.MatchedJob
+	for _, matched := range matchedJobs {
+		seen, err := e.storage.IsSeen(matched.Job.ID)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to check if seen")
+			continue
+		}
+		if !seen {
+			newJobs = append(newJobs, matched)
+		} else {
+			stats.JobsSkipped++
+		}
+	}
+
+	log.Info().Int("new", len(newJobs)).Int("skipped", stats.JobsSkipped).Msg("Filtered seen jobs")
+
+	// 4. Queue new jobs on the channels they are routed to, then send
+	// everything due, including retries of earlier failures
+	now := time.Now()
+	for _, matched := range newJobs {
+		queued, err := e.enqueue(matched, now)
+		if err != nil {
+			log.Error().Err(err).Str("job", matched.Job.ID).Msg("Failed to queue notification")
+			if queued == 0 {
+				continue // Retried on the next check
+			}
+		}
+		if queued == 0 {
+			log.Debug().Str("job", matched.Job.ID).Msg("No route matched job")
+		}
+		e.storage.MarkSeen(matched.Job.ID, matched.Job.Title, matched.Job.URL)
+		if err := e.storage.SaveSearchMatches(matched); err != nil {
+			log.Error().Err(err).Msg("Failed to save search matches")
+		}
+	}
+	stats.JobsNotified = e.deliver(now)
+
+	stats.ErrorMessage = strings.Join(runErrors, "; ")
+	stats.Finish()
+
+	// Save run log
+	if err := e.storage.SaveRunLog(stats); err != nil {
+		log.Error().Err(err).Msg("Failed to save run log")
+	}
+
+	// Cleanup old records
+	if err := e.storage.Cleanup(e.config.Storage.EffectiveRetention()); err != nil {
+		log.Error().Err(err).Msg("Failed to cleanup old records")
+	}
+
+	log.Info().Int("notified", stats.JobsNotified).Float64("duration", stats.DurationSeconds).Msg("Check completed")
+
+	return stats, nil
+}
+
+// DryRunMatch is a job matched during a dry run
+type DryRunMatch struct {
+	*model.MatchedJob
+	Seen bool `json:"seen"` // Already notified, so a real check would skip it
+}
+
+// DryRun executes the fetch and filter steps of a check cycle without
+// sending notifications or writing anything to storage. Matches are
+// returned best score first.
+func (e *Engine) DryRun() (*model.RunStats, []*DryRunMatch, error) {
+	stats := model.NewRunStats()
+
+	allJobs, feedNames := e.fetch(stats)
+	stats.JobsFetched = len(allJobs)
+	stats.ErrorMessage = sourceErrors(stats)
+
+	_, matchedJobs := e.match(allJobs, feedNames, nil)
+	stats.JobsMatched = len(matchedJobs)
+
+	matches := make([]*DryRunMatch, 0, len(matchedJobs))
+	for _, matched := range matchedJobs {
+		seen, err := e.storage.IsSeen(matched.Job.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check if seen: %w", err)
+		}
+		if seen {
+			stats.JobsSkipped++
+		}
+		matches = append(matches, &DryRunMatch{MatchedJob: matched, Seen: seen})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].MatchScore > matches[j].MatchScore
+	})
+
+	stats.Finish()
+	log.Info().Int("matched", stats.JobsMatched).Int("seen", stats.JobsSkipped).Msg("Dry run completed")
+
+	return stats, matches, nil
+}
+
+// fetch retrieves jobs from the configured sources, returning each job with
+// the name of the search or feed it came from
+func (e *Engine) fetch(stats *model.RunStats) ([]*model.Job, []string) {
 	log.Info().Msg("Fetching jobs...")
 
-	// 1. Fetch jobs from configured sources
 	var allJobs []*model.Job
 	var feedNames []string // Track which feeds/searches the jobs came from
 
@@ -82,7 +279,9 @@ func (e *Engine) Run() (*model.RunStats, error) {
 				if limit <= 0 {
 					limit = 50
 				}
-				jobs, err := e.apiFetcher.FetchJobs(keyword, limit)
+				jobs, err := e.fetchSource(stats, model.SourceAPI, search.Name, keyword, func() ([]*model.Job, error) {
+					return e.apiFetcher.FetchJobs(keyword, limit)
+				})
 				if err != nil {
 					log.Error().Err(err).Str("search", search.Name).Str("keyword", keyword).Msg("Failed to fetch from API")
 					continue
@@ -99,7 +298,9 @@ func (e *Engine) Run() (*model.RunStats, error) {
 	if !e.config.UpworkAPI.Enabled && len(e.config.RSSFeeds) > 0 {
 		log.Info().Msg("Using RSS feeds")
 		for _, feed := range e.config.RSSFeeds {
-			jobs, err := e.rssFetcher.FetchFromURL(feed.URL)
+			jobs, err := e.fetchSource(stats, model.SourceRSS, feed.Name, "", func() ([]*model.Job, error) {
+				return e.rssFetcher.FetchFromURL(feed.URL)
+			})
 			if err != nil {
 				log.Error().Err(err).Str("feed", feed.Name).Msg("Failed to fetch RSS feed")
 				continue
@@ -116,7 +317,9 @@ func (e *Engine) Run() (*model.RunStats, error) {
 	if !e.config.UpworkAPI.Enabled && len(e.config.RSSFeeds) == 0 && len(e.config.Searches) > 0 {
 		log.Warn().Msg("Using deprecated keyword RSS search - this no longer works with Upwork")
 		for _, search := range e.config.Searches {
-			jobs, err := e.rssFetcher.Fetch(search.Keywords)
+			jobs, err := e.fetchSource(stats, model.SourceRSSSearch, search.Name, "", func() ([]*model.Job, error) {
+				return e.rssFetcher.Fetch(search.Keywords)
+			})
 			if err != nil {
 				log.Error().Err(err).Str("search", search.Name).Msg("Failed to fetch")
 				continue
@@ -128,26 +331,44 @@ func (e *Engine) Run() (*model.RunStats, error) {
 		}
 	}
 
-	stats.JobsFetched = len(allJobs)
-	log.Info().Int("total", stats.JobsFetched).Msg("Total jobs fetched")
+	return allJobs, feedNames
+}
+
+// match filters fetched jobs against the searches that returned them. It
+// returns a record per unique job and the jobs that matched at least one
+// search. at gives the time each job is filtered as of; nil means now.
+func (e *Engine) match(allJobs []*model.Job, feedNames []string, at func(*model.Job) time.Time) ([]*model.JobRecord, []*model.MatchedJob) {
+	now := time.Now()
 
-	// 2. Filter and match jobs
 	log.Info().Msg("Filtering jobs...")
 	var matchedJobs []*model.MatchedJob
 
-	// Deduplicate jobs by ID
-	seen := make(map[string]bool)
+	// Deduplicate jobs by ID, keeping every search that found them
+	matchedByID := make(map[string]*model.MatchedJob)
+	recordsByID := make(map[string]*model.JobRecord)
+	var records []*model.JobRecord
+	checked := make(map[string]bool)
 
 	for i, job := range allJobs {
-		// Skip duplicates
-		if seen[job.ID] {
+		feedName := feedNames[i]
+
+		// Skip repeats of the same job from the same search
+		key := job.ID + "\x00" + feedName
+		if checked[key] {
 			continue
 		}
-		seen[job.ID] = true
+		checked[key] = true
+
+		record, ok := recordsByID[job.ID]
+		if !ok {
+			record = &model.JobRecord{Job: job}
+			recordsByID[job.ID] = record
+			records = append(records, record)
+		}
+		record.Searches = append(record.Searches, feedName)
 
 		// Get keywords to match against
 		var keywords []string
-		feedName := feedNames[i]
 
 		// Find the search config for this job
 		for _, search := range e.config.Searches {
@@ -162,123 +383,147 @@ func (e *Engine) Run() (*model.RunStats, error) {
 			keywords = []string{feedName}
 		}
 
-		matchedKeywords := e.filter.Match(job, keywords)
-		if len(matchedKeywords) > 0 {
-			matchedJobs = append(matchedJobs, model.NewMatchedJob(job, matchedKeywords, feedName))
+		when := now
+		if at != nil {
+			when = at(job)
 		}
-	}
-
-	stats.JobsMatched = len(matchedJobs)
-	log.Info().Int("matched", stats.JobsMatched).Msg("Jobs matched")
-
-	// 3. Filter out already seen jobs
-	var newJobs []*model.MatchedJob
-	for _, matched := range matchedJobs {
-		seen, err := e.storage.IsSeen(matched.Job.ID)
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to check if seen")
+		matchedKeywords := e.filter.MatchAt(job, keywords, when)
+		if len(matchedKeywords) == 0 {
 			continue
 		}
-		if !seen {
-			newJobs = append(newJobs, matched)
-		} else {
-			stats.JobsSkipped++
-		}
-	}
-
-	log.Info().Int("new", len(newJobs)).Int("skipped", stats.JobsSkipped).Msg("Filtered seen jobs")
 
-	// 4. Send notifications
-	if len(newJobs) > 0 {
-		log.Info().Int("count", len(newJobs)).Msg("Sending notifications...")
-
-		for _, matched := range newJobs {
-			if e.notify(matched) {
-				stats.JobsNotified++
-				e.storage.MarkSeen(matched.Job.ID, matched.Job.Title, matched.Job.URL)
-			}
+		matched, ok := matchedByID[job.ID]
+		if !ok {
+			matched = &model.MatchedJob{Job: job}
+			matchedByID[job.ID] = matched
+			matchedJobs = append(matchedJobs, matched)
 		}
+		matched.AddMatch(feedName, matchedKeywords, filter.Score(matchedKeywords, keywords))
+		record.Matches = matched.Matches
+		record.MatchScore = matched.MatchScore
 	}
 
-	stats.Finish()
+	return records, matchedJobs
+}
 
-	// Save run log
-	if err := e.storage.SaveRunLog(stats); err != nil {
-		log.Error().Err(err).Msg("Failed to save run log")
+// sourceErrors summarizes failed fetches for the run log, empty if none failed
+func sourceErrors(stats *model.RunStats) string {
+	failed := stats.FailedSources()
+	if len(failed) == 0 {
+		return ""
 	}
+	return fmt.Sprintf("%d of %d sources failed (%s: %s)",
+		len(failed), len(stats.Sources), failed[0].Label(), failed[0].ErrorMessage)
+}
 
-	// Cleanup old records
-	if err := e.storage.Cleanup(e.config.Storage.RetentionDays); err != nil {
-		log.Error().Err(err).Msg("Failed to cleanup old records")
+// fetchSource runs one fetch and records its outcome in stats
+func (e *Engine) fetchSource(stats *model.RunStats, source, searchName, keyword string, fetch func() ([]*model.Job, error)) ([]*model.Job, error) {
+	result := &model.SourceResult{
+		Source:     source,
+		SearchName: searchName,
+		Keyword:    keyword,
+		StartedAt:  time.Now(),
 	}
 
-	log.Info().Int("notified", stats.JobsNotified).Float64("duration", stats.DurationSeconds).Msg("Check completed")
-
-	return stats, nil
-}
+	jobs, err := fetch()
 
-// notify sends notifications to all enabled channels
-func (e *Engine) notify(matched *model.MatchedJob) bool {
-	success := false
-
-	for _, n := range e.notifiers {
-		if err := n.Send(matched); err != nil {
-			log.Error().Err(err).Str("channel", n.Name()).Msg("Failed to send notification")
-
-			e.storage.SaveNotifyRecord(&model.NotifyRecord{
-				JobID:           matched.Job.ID,
-				JobTitle:        matched.Job.Title,
-				JobURL:          matched.Job.URL,
-				SearchName:      matched.SearchName,
-				MatchedKeywords: strings.Join(matched.MatchedKeywords, ","),
-				NotifyChannel:   n.Name(),
-				Status:          model.NotifyStatusFailed,
-				ErrorMessage:    err.Error(),
-				CreatedAt:       time.Now(),
-			})
-		} else {
-			success = true
-			now := time.Now()
-
-			e.storage.SaveNotifyRecord(&model.NotifyRecord{
-				JobID:           matched.Job.ID,
-				JobTitle:        matched.Job.Title,
-				JobURL:          matched.Job.URL,
-				SearchName:      matched.SearchName,
-				MatchedKeywords: strings.Join(matched.MatchedKeywords, ","),
-				NotifyChannel:   n.Name(),
-				Status:          model.NotifyStatusSent,
-				CreatedAt:       now,
-				SentAt:          &now,
-			})
-
-			log.Debug().Str("channel", n.Name()).Str("job", matched.Job.Title).Msg("Notification sent")
-		}
+	result.DurationSeconds = time.Since(result.StartedAt).Seconds()
+	result.Fetched = len(jobs)
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		result.HTTPStatus = fetcher.StatusCode(err)
+	} else {
+		// Fetchers only succeed on 200 OK
+		result.HTTPStatus = http.StatusOK
 	}
+	stats.Sources = append(stats.Sources, result)
 
-	return success
+	return jobs, err
 }
 
 // StartScheduler starts the scheduled job monitoring
 func (e *Engine) StartScheduler() {
+	if !e.storage.FullTextSearch() {
+		log.Warn().Msg("SQLite was built without FTS5 (build tag sqlite_fts5); job search falls back to unranked LIKE matching")
+	}
+
 	e.scheduler = scheduler.New(e.config.Schedule)
 	e.scheduler.AddJob(func() {
+		if e.Paused() {
+			log.Info().Msg("Paused, skipping scheduled check")
+			return
+		}
 		if _, err := e.Run(); err != nil {
 			log.Error().Err(err).Msg("Scheduled check failed")
 		}
 	})
+
+	if backup := e.config.Storage.Backup; backup.Enabled {
+		interval := time.Duration(backup.IntervalHours) * time.Hour
+		if err := e.scheduler.AddPeriodicJob(interval, e.backup); err != nil {
+			log.Error().Err(err).Msg("Failed to schedule backups")
+		}
+	}
+
 	e.scheduler.Start()
 }
 
-// StopScheduler stops the scheduled job monitoring
+// backup takes a scheduled database backup and removes the oldest ones
+func (e *Engine) backup() {
+	cfg := e.config.Storage
+	path := storage.BackupPath(cfg.Backup.Dir, cfg.Database, time.Now())
+
+	if err := e.storage.Backup(path); err != nil {
+		log.Error().Err(err).Msg("Scheduled backup failed")
+		return
+	}
+	log.Info().Str("path", path).Msg("Database backed up")
+
+	removed, err := storage.RotateBackups(cfg.Backup.Dir, cfg.Database, cfg.Backup.Keep)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to rotate backups")
+	}
+	for _, old := range removed {
+		log.Debug().Str("path", old).Msg("Removed old backup")
+	}
+}
+
+// StopScheduler stops the scheduled job monitoring and the Telegram bot
 func (e *Engine) StopScheduler() {
+	if e.stopBot != nil {
+		e.stopBot()
+	}
 	if e.scheduler != nil {
 		e.scheduler.Stop()
 	}
 }
 
+// StartBot starts the interactive Telegram bot in the background
+func (e *Engine) StartBot() {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.stopBot = cancel
+	go bot.New(e.config, e.storage, e).Run(ctx)
+}
+
+// Pause skips scheduled checks until Resume
+func (e *Engine) Pause() {
+	e.paused.Store(true)
+	log.Info().Msg("Scheduled checks paused")
+}
+
+// Resume resumes scheduled checks
+func (e *Engine) Resume() {
+	e.paused.Store(false)
+	log.Info().Msg("Scheduled checks resumed")
+}
+
+// Paused reports whether scheduled checks are paused
+func (e *Engine) Paused() bool {
+	return e.paused.Load()
+}
+
 // GetStorage returns the storage instance
-func (e *Engine) GetStorage() *storage.Storage {
+func (e *Engine) GetStorage() storage.Store {
 	return e.storage
 }
 
@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"jobradar/internal/model"
+	"jobradar/internal/notifier"
+
+	"github.com/rs/zerolog/log"
+)
+
+// claimTimeout is how long a notification being sent is hidden from other
+// processes. A process that dies mid-send leaves it to be retried after this.
+const claimTimeout = 10 * time.Minute
+
+// enqueue queues a matched job for delivery on every notification channel
+// its routes send it to, returning how many channels it was queued on.
+// Channels in digest mode hold it until their next digest and channels in
+// quiet hours until they end.
+func (e *Engine) enqueue(matched *model.MatchedJob, now time.Time) (int, error) {
+	routed := routeChannels(e.config.Notifications.Routes, matched)
+	queued := 0
+	for _, n := range e.notifiers {
+		if routed != nil && !routed[n.Name()] {
+			continue
+		}
+		due := now
+		if d := e.digests[n.Name()]; d != nil {
+			due = d.next(now)
+		}
+		if q := e.quiet[n.Name()]; q != nil && q.contains(due) {
+			due = q.until(due)
+		}
+		record := &model.NotifyRecord{
+			JobID:           matched.Job.ID,
+			JobTitle:        matched.Job.Title,
+			JobURL:          matched.Job.URL,
+			SearchName:      strings.Join(matched.SearchNames(), ","),
+			MatchedKeywords: strings.Join(matched.MatchedKeywords, ","),
+			NotifyChannel:   n.Name(),
+			Status:          model.NotifyStatusPending,
+			CreatedAt:       now,
+			NextAttemptAt:   &due,
+			Job:             matched,
+		}
+		if err := e.storage.SaveNotifyRecord(record); err != nil {
+			return queued, err
+		}
+		queued++
+	}
+	return queued, nil
+}
+
+// deliver sends every notification due at now, new ones and retries of
+// earlier failures alike, and returns how many distinct jobs were sent.
+// Channels in digest mode send all of theirs as one digest, as do other
+// channels able to for the jobs held through their quiet hours. Channels
+// in quiet hours send nothing until they end.
+func (e *Engine) deliver(now time.Time) int {
+	due, err := e.storage.DueNotifications(now, 0)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get due notifications")
+		return 0
+	}
+	if len(due) == 0 {
+		return 0
+	}
+
+	log.Info().Int("count", len(due)).Msg("Sending notifications...")
+
+	channels := make(map[string]notifier.Notifier, len(e.notifiers))
+	for _, n := range e.notifiers {
+		channels[n.Name()] = n
+	}
+
+	digests := make(map[string][]*model.NotifyRecord)
+	var order []string
+	for _, r := range due {
+		// Retries falling in quiet hours are put off until they end
+		until := now.Add(claimTimeout)
+		quiet := e.quiet[r.NotifyChannel]
+		held := quiet != nil && quiet.contains(now)
+		if held {
+			until = quiet.until(now)
+		}
+
+		claimed, err := e.storage.ClaimNotification(r.ID, now, until)
+		if err != nil {
+			log.Error().Err(err).Int64("id", r.ID).Msg("Failed to claim notification")
+			continue
+		}
+		if !claimed || held {
+			continue // Another process is sending it, or held until quiet hours end
+		}
+
+		n := channels[r.NotifyChannel]
+		batched := e.digests[r.NotifyChannel] != nil || quiet != nil && quiet.contains(r.CreatedAt)
+		if _, ok := n.(notifier.DigestNotifier); ok && batched {
+			if digests[r.NotifyChannel] == nil {
+				order = append(order, r.NotifyChannel)
+			}
+			digests[r.NotifyChannel] = append(digests[r.NotifyChannel], r)
+			continue
+		}
+		e.attempt(r, n, now)
+	}
+	for _, channel := range order {
+		records := digests[channel]
+		if len(records) == 1 && e.digests[channel] == nil {
+			e.attempt(records[0], channels[channel], now) // A lone held job goes out as a normal alert
+			continue
+		}
+		e.sendDigest(records, channels[channel].(notifier.DigestNotifier), now)
+	}
+
+	sent := make(map[string]bool)
+	for _, r := range due {
+		if r.Status == model.NotifyStatusSent {
+			sent[r.JobID] = true
+		}
+	}
+	return len(sent)
+}
+
+// attempt sends one notification and records the outcome
+func (e *Engine) attempt(r *model.NotifyRecord, n notifier.Notifier, now time.Time) {
+	switch {
+	case n == nil:
+		e.settle(r, fmt.Errorf("channel %s is no longer enabled", r.NotifyChannel), true, now)
+	case r.Job == nil:
+		e.settle(r, errors.New("no job stored with the notification"), true, now)
+	default:
+		e.settle(r, n.Send(r.Job), false, now)
+	}
+}
+
+// settle records the outcome of an attempt to send a notification,
+// scheduling a retry with backoff or giving up once attempts run out, it
+// expires or the failure is final
+func (e *Engine) settle(r *model.NotifyRecord, err error, final bool, now time.Time) {
+	retry := e.config.Notifications.Retry
+	r.Attempts++
+
+	if err == nil {
+		r.Status = model.NotifyStatusSent
+		r.ErrorMessage = ""
+		r.SentAt = &now
+		r.NextAttemptAt = nil
+		log.Debug().Str("channel", r.NotifyChannel).Str("job", r.JobTitle).Msg("Notification sent")
+	} else {
+		r.ErrorMessage = err.Error()
+		next := now.Add(retryDelay(retry.BackoffMinutes, r.Attempts))
+		expires := r.CreatedAt.Add(time.Duration(retry.ExpireHours) * time.Hour)
+
+		if final || r.Attempts >= retry.MaxAttempts || next.After(expires) {
+			r.Status = model.NotifyStatusFailed
+			r.NextAttemptAt = nil
+			log.Error().Err(err).Str("channel", r.NotifyChannel).Int("attempts", r.Attempts).
+				Msg("Giving up on notification")
+		} else {
+			r.NextAttemptAt = &next
+			log.Warn().Err(err).Str("channel", r.NotifyChannel).Time("retry_at", next).
+				Msg("Failed to send notification, will retry")
+		}
+	}
+
+	if err := e.storage.UpdateNotifyRecord(r); err != nil {
+		log.Error().Err(err).Int64("id", r.ID).Msg("Failed to save notification result")
+	}
+}
+
+// retryDelay returns the wait before the next attempt after the given
+// number of failed attempts, doubling from backoffMinutes
+func retryDelay(backoffMinutes, attempts int) time.Duration {
+	delay := time.Duration(backoffMinutes) * time.Minute
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+	}
+	return delay
+}
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+	"jobradar/internal/notifier"
+	"jobradar/internal/storage"
+)
+
+// flakyNotifier fails its next failures sends and records the rest
+type flakyNotifier struct {
+	name     string
+	failures int
+	sent     []string
+}
+
+func (n *flakyNotifier) Name() string { return n.name }
+
+func (n *flakyNotifier) Send(matched *model.MatchedJob) error {
+	if n.failures > 0 {
+		n.failures--
+		return errors.New("connection refused")
+	}
+	n.sent = append(n.sent, matched.Job.ID)
+	return nil
+}
+
+// skipWithoutCGO skips a test that needs SQLite when err says this build
+// has no cgo
+func skipWithoutCGO(t *testing.T, err error) {
+	t.Helper()
+	if errors.Is(err, storage.ErrSQLiteCGO) {
+		t.Skip(err)
+	}
+}
+
+func TestEngine_DeliverRetries(t *testing.T) {
+	store, err := storage.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	defer store.Close()
+
+	telegram := &flakyNotifier{name: "telegram"}
+	email := &flakyNotifier{name: "email", failures: 1}
+	cfg := &config.AppConfig{
+		Notifications: config.NotificationConfig{
+			Retry: config.RetryConfig{MaxAttempts: 3, BackoffMinutes: 5, ExpireHours: 24},
+		},
+	}
+	e := &Engine{config: cfg, storage: store, notifiers: []notifier.Notifier{telegram, email}}
+
+	now := time.Now()
+	matched := model.NewMatchedJob(&model.Job{ID: "~01a", Title: "Go API"}, []string{"golang"}, "Golang", 1)
+	if queued, err := e.enqueue(matched, now); err != nil || queued != 2 {
+		t.Fatalf("enqueue() = %d, %v, want 2 channels", queued, err)
+	}
+
+	if n := e.deliver(now); n != 1 {
+		t.Errorf("deliver() = %d, want 1 job sent", n)
+	}
+	if len(telegram.sent) != 1 || len(email.sent) != 0 {
+		t.Fatalf("sent telegram=%v email=%v, want only telegram", telegram.sent, email.sent)
+	}
+
+	// The email retry is not due before the backoff has passed
+	e.deliver(now.Add(time.Minute))
+	if len(email.sent) != 0 {
+		t.Error("email was retried before its backoff")
+	}
+
+	e.deliver(now.Add(6 * time.Minute))
+	if len(email.sent) != 1 || len(telegram.sent) != 1 {
+		t.Errorf("sent telegram=%v email=%v, want each sent once", telegram.sent, email.sent)
+	}
+
+	stuck, err := store.QueryNotifyRecords(model.NotifyQuery{Stuck: true})
+	if err != nil {
+		t.Fatalf("QueryNotifyRecords() error = %v", err)
+	}
+	if len(stuck) != 0 {
+		t.Errorf("stuck records = %+v, want none", stuck)
+	}
+
+	// A channel that keeps failing gives up after max attempts
+	email.failures = 10
+	matched = model.NewMatchedJob(&model.Job{ID: "~01b", Title: "Go CLI"}, []string{"golang"}, "Golang", 1)
+	e.enqueue(matched, now)
+	for _, at := range []time.Duration{0, 5 * time.Minute, 15 * time.Minute, 35 * time.Minute} {
+		e.deliver(now.Add(at))
+	}
+
+	failed, _ := store.QueryNotifyRecords(model.NotifyQuery{Status: model.NotifyStatusFailed})
+	if len(failed) != 1 || failed[0].Attempts != 3 || failed[0].ErrorMessage != "connection refused" {
+		t.Errorf("failed records = %+v, want email given up after 3 attempts", failed)
+	}
+}
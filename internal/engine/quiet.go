@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"jobradar/internal/config"
+)
+
+// quietHours is a daily period in which a channel's notifications are held
+// and sent together when it ends. It spans midnight when start is after end.
+type quietHours struct {
+	start    time.Duration // Offsets from midnight
+	end      time.Duration
+	location *time.Location
+}
+
+// newQuietHours parses a channel's quiet hours
+func newQuietHours(cfg config.QuietHours) (*quietHours, error) {
+	q := &quietHours{location: time.UTC}
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone: %w", err)
+		}
+		q.location = loc
+	}
+
+	for _, t := range []struct {
+		value  string
+		offset *time.Duration
+	}{
+		{cfg.Start, &q.start},
+		{cfg.End, &q.end},
+	} {
+		parsed, err := time.Parse("15:04", t.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q", t.value)
+		}
+		*t.offset = time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute
+	}
+	if q.start == q.end {
+		return nil, fmt.Errorf("start and end must differ")
+	}
+	return q, nil
+}
+
+// channelQuietHours returns the quiet hours of every enabled channel that
+// has them, keyed by channel name
+func channelQuietHours(cfg *config.AppConfig) (map[string]*quietHours, error) {
+	configured := cfg.ChannelQuietHours()
+	names := make([]string, 0, len(configured))
+	for name := range configured {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	quiet := make(map[string]*quietHours)
+	for _, name := range names {
+		q, err := newQuietHours(configured[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s quiet hours: %w", name, err)
+		}
+		quiet[name] = q
+	}
+	return quiet, nil
+}
+
+// contains reports whether t falls within quiet hours
+func (q *quietHours) contains(t time.Time) bool {
+	local := t.In(q.location)
+	offset := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	if q.start < q.end {
+		return offset >= q.start && offset < q.end
+	}
+	return offset >= q.start || offset < q.end
+}
+
+// until returns when the quiet period containing t ends
+func (q *quietHours) until(t time.Time) time.Time {
+	local := t.In(q.location)
+	day := local.Day()
+	// Overnight periods entered before midnight end the next day
+	if q.start > q.end && time.Duration(local.Hour())*time.Hour+time.Duration(local.Minute())*time.Minute >= q.start {
+		day++
+	}
+	return time.Date(local.Year(), local.Month(), day,
+		int(q.end/time.Hour), int(q.end%time.Hour/time.Minute), 0, 0, q.location)
+}
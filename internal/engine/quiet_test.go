@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+	"jobradar/internal/notifier"
+	"jobradar/internal/storage"
+)
+
+func TestQuietHours(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	at := func(loc *time.Location, day, hour, min int) time.Time {
+		return time.Date(2024, 3, day, hour, min, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name   string
+		config config.QuietHours
+		now    time.Time
+		quiet  bool
+		until  time.Time
+	}{
+		{"overnight before midnight", config.QuietHours{Start: "23:00", End: "07:00"}, at(time.UTC, 1, 23, 30), true, at(time.UTC, 2, 7, 0)},
+		{"overnight after midnight", config.QuietHours{Start: "23:00", End: "07:00"}, at(time.UTC, 2, 3, 0), true, at(time.UTC, 2, 7, 0)},
+		{"overnight end", config.QuietHours{Start: "23:00", End: "07:00"}, at(time.UTC, 2, 7, 0), false, time.Time{}},
+		{"same day", config.QuietHours{Start: "12:00", End: "14:00"}, at(time.UTC, 1, 12, 0), true, at(time.UTC, 1, 14, 0)},
+		{"same day outside", config.QuietHours{Start: "12:00", End: "14:00"}, at(time.UTC, 1, 11, 59), false, time.Time{}},
+		{"timezone", config.QuietHours{Start: "22:00", End: "06:00", Timezone: "Europe/Berlin"}, at(time.UTC, 1, 21, 30), true, at(berlin, 2, 6, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := newQuietHours(tt.config)
+			if err != nil {
+				t.Fatalf("newQuietHours() error = %v", err)
+			}
+			if got := q.contains(tt.now); got != tt.quiet {
+				t.Fatalf("contains(%v) = %v, want %v", tt.now, got, tt.quiet)
+			}
+			if tt.quiet {
+				if got := q.until(tt.now); !got.Equal(tt.until) {
+					t.Errorf("until(%v) = %v, want %v", tt.now, got, tt.until)
+				}
+			}
+		})
+	}
+}
+
+func TestEngine_DeliverQuietHours(t *testing.T) {
+	store, err := storage.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	defer store.Close()
+
+	telegram := &digestNotifier{flakyNotifier: flakyNotifier{name: "telegram"}}
+	slack := &flakyNotifier{name: "slack"}
+	discord := &digestNotifier{flakyNotifier: flakyNotifier{name: "discord"}}
+	cfg := &config.AppConfig{
+		Notifications: config.NotificationConfig{
+			Telegram: config.TelegramConfig{Enabled: true},
+			Slack:    config.SlackConfig{Enabled: true},
+			Discord:  config.DiscordConfig{Enabled: true, QuietHours: &config.QuietHours{Enabled: false}},
+			Retry:    config.RetryConfig{MaxAttempts: 3, BackoffMinutes: 5, ExpireHours: 24},
+		},
+		Schedule: config.ScheduleConfig{
+			QuietHours: config.QuietHours{Enabled: true, Start: "23:00", End: "07:00"},
+		},
+	}
+	quiet, err := channelQuietHours(cfg)
+	if err != nil {
+		t.Fatalf("channelQuietHours() error = %v", err)
+	}
+	e := &Engine{config: cfg, storage: store, notifiers: []notifier.Notifier{telegram, slack, discord}, quiet: quiet}
+
+	night := time.Date(2024, 3, 1, 23, 30, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		matched := model.NewMatchedJob(&model.Job{ID: fmt.Sprintf("~0%d", i)}, []string{"golang"}, "Golang", 1)
+		if _, err := e.enqueue(matched, night.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatalf("enqueue() error = %v", err)
+		}
+	}
+
+	e.deliver(night.Add(2 * time.Hour))
+	if len(discord.sent) != 2 {
+		t.Errorf("discord sent %v, want both jobs despite the default quiet hours", discord.sent)
+	}
+	if len(telegram.sent)+len(telegram.digests)+len(slack.sent) != 0 {
+		t.Fatalf("sent during quiet hours: telegram=%v slack=%v", telegram.digests, slack.sent)
+	}
+
+	e.deliver(time.Date(2024, 3, 2, 7, 5, 0, 0, time.UTC))
+	if len(telegram.digests) != 1 || len(telegram.digests[0]) != 2 || len(telegram.sent) != 0 {
+		t.Errorf("telegram digests = %v, sent = %v, want one morning digest", telegram.digests, telegram.sent)
+	}
+	if len(slack.sent) != 2 {
+		t.Errorf("slack sent %v, want each job one by one", slack.sent)
+	}
+}
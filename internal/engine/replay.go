@@ -0,0 +1,206 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+// ReplayJob is a job whose match result differs between a replay and what
+// was actually notified
+type ReplayJob struct {
+	JobID    string   `json:"job_id"`
+	Title    string   `json:"title"`
+	URL      string   `json:"url"`
+	Score    float64  `json:"score"` // Replayed score, or the recorded score of a dropped job
+	Keywords []string `json:"keywords"`
+}
+
+// SearchDiff compares the jobs a search matches on replay with the jobs it
+// actually notified
+type SearchDiff struct {
+	SearchName string       `json:"search_name"`
+	Notified   int          `json:"notified"` // Stored jobs the search actually notified
+	Matched    int          `json:"matched"`  // Stored jobs the search matches on replay
+	Kept       int          `json:"kept"`     // Jobs in both
+	Added      []*ReplayJob `json:"added"`    // Matched on replay but not notified by this search
+	Dropped    []*ReplayJob `json:"dropped"`  // Notified by this search but no longer matched
+}
+
+// Changed reports whether the replay differs from what the search notified
+func (d *SearchDiff) Changed() bool {
+	return len(d.Added) > 0 || len(d.Dropped) > 0
+}
+
+// ReplayReport is the result of replaying stored jobs through the
+// configured filters and searches
+type ReplayReport struct {
+	Since            time.Time     `json:"since"`
+	Until            time.Time     `json:"until,omitempty"`
+	JobsReplayed     int           `json:"jobs_replayed"`
+	JobsNotified     int           `json:"jobs_notified"`     // Distinct jobs notified in the window
+	JobsMatched      int           `json:"jobs_matched"`      // Distinct jobs matched on replay
+	NewNotifications int           `json:"new_notifications"` // Matched jobs that were never notified
+	NotStored        int           `json:"not_stored"`        // Notified jobs no longer stored, left out of the diff
+	Searches         []*SearchDiff `json:"searches"`
+}
+
+// Replay runs the configured filters and scoring over jobs stored between
+// since and until and compares the result with what was actually notified
+// in that window. Nothing is sent or written.
+//
+// Every stored job is matched against every configured search, as if each
+// search had fetched it, and against the RSS feeds that returned it. Each
+// job is filtered as of when it was first seen, so the posted time window
+// applies as it did then.
+func (e *Engine) Replay(since, until time.Time) (*ReplayReport, error) {
+	records, err := e.storage.QueryJobs(model.JobQuery{Since: since, Until: until})
+	if err != nil {
+		return nil, err
+	}
+	notified, err := e.storage.QuerySearchMatches(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	searchNames := make(map[string]bool)
+	for _, search := range e.config.Searches {
+		searchNames[search.Name] = true
+	}
+	feedNames := make(map[string]bool)
+	for _, feed := range e.config.RSSFeeds {
+		if !searchNames[feed.Name] {
+			feedNames[feed.Name] = true
+		}
+	}
+
+	// Pair each stored job with every search that would filter it
+	byID := make(map[string]*model.JobRecord, len(records))
+	firstSeen := make(map[*model.Job]time.Time, len(records))
+	var jobs []*model.Job
+	var sources []string
+	for _, r := range records {
+		byID[r.Job.ID] = r
+		firstSeen[r.Job] = r.FirstSeenAt
+		for _, search := range e.config.Searches {
+			jobs = append(jobs, r.Job)
+			sources = append(sources, search.Name)
+		}
+		for _, name := range r.Searches {
+			if feedNames[name] {
+				jobs = append(jobs, r.Job)
+				sources = append(sources, name)
+			}
+		}
+	}
+
+	_, matchedJobs := e.match(jobs, sources, func(job *model.Job) time.Time {
+		return firstSeen[job]
+	})
+
+	result := &ReplayReport{
+		Since:        since,
+		Until:        until,
+		JobsReplayed: len(records),
+		JobsMatched:  len(matchedJobs),
+	}
+
+	// Replayed and actual matches by search, then job ID
+	replayed := make(map[string]map[string]*ReplayJob)
+	actual := make(map[string]map[string]*model.SearchMatchRecord)
+	var order []string
+	addSearch := func(name string) {
+		if _, ok := replayed[name]; !ok {
+			replayed[name] = make(map[string]*ReplayJob)
+			actual[name] = make(map[string]*model.SearchMatchRecord)
+			order = append(order, name)
+		}
+	}
+	for _, search := range e.config.Searches {
+		addSearch(search.Name)
+	}
+	for _, feed := range e.config.RSSFeeds {
+		addSearch(feed.Name)
+	}
+
+	notifiedJobs := make(map[string]bool)
+	notStored := make(map[string]bool)
+	for _, n := range notified {
+		notifiedJobs[n.JobID] = true
+		if byID[n.JobID] == nil {
+			notStored[n.JobID] = true
+			continue
+		}
+		addSearch(n.SearchName)
+		actual[n.SearchName][n.JobID] = n
+	}
+	result.JobsNotified = len(notifiedJobs)
+	result.NotStored = len(notStored)
+
+	for _, m := range matchedJobs {
+		for _, sm := range m.Matches {
+			addSearch(sm.SearchName)
+			replayed[sm.SearchName][m.Job.ID] = &ReplayJob{
+				JobID:    m.Job.ID,
+				Title:    m.Job.Title,
+				URL:      m.Job.URL,
+				Score:    sm.MatchScore,
+				Keywords: sm.MatchedKeywords,
+			}
+		}
+
+		if !notifiedJobs[m.Job.ID] {
+			seen, err := e.storage.IsSeen(m.Job.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check if seen: %w", err)
+			}
+			if !seen {
+				result.NewNotifications++
+			}
+		}
+	}
+
+	for _, name := range order {
+		d := &SearchDiff{
+			SearchName: name,
+			Notified:   len(actual[name]),
+			Matched:    len(replayed[name]),
+		}
+		for id, job := range replayed[name] {
+			if actual[name][id] != nil {
+				d.Kept++
+			} else {
+				d.Added = append(d.Added, job)
+			}
+		}
+		for id, n := range actual[name] {
+			if replayed[name][id] == nil {
+				r := byID[id]
+				d.Dropped = append(d.Dropped, &ReplayJob{
+					JobID:    id,
+					Title:    r.Job.Title,
+					URL:      r.Job.URL,
+					Score:    n.MatchScore,
+					Keywords: n.MatchedKeywords,
+				})
+			}
+		}
+		sortReplayJobs(d.Added)
+		sortReplayJobs(d.Dropped)
+		result.Searches = append(result.Searches, d)
+	}
+
+	return result, nil
+}
+
+// sortReplayJobs orders jobs best score first, then by title
+func sortReplayJobs(jobs []*ReplayJob) {
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].Score != jobs[j].Score {
+			return jobs[i].Score > jobs[j].Score
+		}
+		return jobs[i].Title < jobs[j].Title
+	})
+}
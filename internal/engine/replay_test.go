@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/filter"
+	"jobradar/internal/model"
+	"jobradar/internal/storage"
+)
+
+func TestEngine_Replay(t *testing.T) {
+	store, err := storage.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	jobs := []*model.Job{
+		{ID: "~01api", Title: "Golang API", Description: "REST API in golang", JobType: model.JobTypeFixed, PostedAt: now.Add(-2 * time.Hour)},
+		{ID: "~01wp", Title: "WordPress site", Description: "golang not needed, php", JobType: model.JobTypeFixed, PostedAt: now.Add(-2 * time.Hour)},
+		{ID: "~01cli", Title: "Go CLI tool", Description: "golang command line", JobType: model.JobTypeFixed, PostedAt: now.Add(-2 * time.Hour)},
+	}
+	var records []*model.JobRecord
+	for _, job := range jobs {
+		records = append(records, &model.JobRecord{Job: job, Searches: []string{"Golang"}})
+	}
+	if err := store.SaveJobs(records); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+
+	// The live config notified the API and WordPress jobs
+	for _, id := range []string{"~01api", "~01wp"} {
+		matched := &model.MatchedJob{
+			Job:     &model.Job{ID: id},
+			Matches: []model.SearchMatch{{SearchName: "Golang", MatchedKeywords: []string{"golang"}, MatchScore: 1}},
+		}
+		if err := store.SaveSearchMatches(matched); err != nil {
+			t.Fatalf("SaveSearchMatches() error = %v", err)
+		}
+		if err := store.MarkSeen(id, "", ""); err != nil {
+			t.Fatalf("MarkSeen() error = %v", err)
+		}
+	}
+
+	// The candidate config excludes php and would pick up the CLI job
+	cfg := &config.AppConfig{
+		Searches: []config.SearchConfig{{Name: "Golang", Keywords: []string{"golang"}}},
+		Filters: config.FilterConfig{
+			Budget:          config.BudgetFilter{Min: 0, Max: 100000},
+			JobType:         config.JobTypeAll,
+			ExcludeKeywords: []string{"php"},
+		},
+	}
+	e := &Engine{config: cfg, storage: store, filter: filter.New(cfg.Filters)}
+
+	report, err := e.Replay(now.Add(-24*time.Hour), time.Time{})
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	if report.JobsReplayed != 3 || report.JobsNotified != 2 || report.JobsMatched != 2 {
+		t.Errorf("Replay() = %+v, want 3 replayed, 2 notified, 2 matched", report)
+	}
+	if report.NewNotifications != 1 {
+		t.Errorf("NewNotifications = %d, want 1", report.NewNotifications)
+	}
+	if len(report.Searches) != 1 {
+		t.Fatalf("Replay() returned %d searches, want 1", len(report.Searches))
+	}
+
+	d := report.Searches[0]
+	if d.Kept != 1 || len(d.Added) != 1 || len(d.Dropped) != 1 {
+		t.Fatalf("Golang diff = %+v, want 1 kept, 1 added, 1 dropped", d)
+	}
+	if d.Added[0].JobID != "~01cli" || d.Dropped[0].JobID != "~01wp" {
+		t.Errorf("added %s, dropped %s; want ~01cli and ~01wp", d.Added[0].JobID, d.Dropped[0].JobID)
+	}
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"strings"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+)
+
+// routeChannels returns the channels a matched job is sent to, or nil for
+// every channel when no routes are configured
+func routeChannels(routes []config.RouteConfig, matched *model.MatchedJob) map[string]bool {
+	if len(routes) == 0 {
+		return nil
+	}
+
+	channels := make(map[string]bool)
+	for _, route := range routes {
+		if !routeMatches(route, matched) {
+			continue
+		}
+		for _, channel := range route.Channels {
+			channels[channel] = true
+		}
+		if route.Final {
+			break
+		}
+	}
+	return channels
+}
+
+// routeMatches reports whether a matched job meets every condition of a
+// route. Budget conditions never match jobs that state no budget.
+func routeMatches(route config.RouteConfig, matched *model.MatchedJob) bool {
+	if len(route.Searches) > 0 && !anyEqual(route.Searches, matched.SearchNames(), false) {
+		return false
+	}
+	if matched.MatchScore < route.MinScore {
+		return false
+	}
+	if route.MinBudget > 0 || route.MaxBudget > 0 {
+		budget, ok := matched.Job.Budget()
+		if !ok || budget < route.MinBudget {
+			return false
+		}
+		if route.MaxBudget > 0 && budget > route.MaxBudget {
+			return false
+		}
+	}
+	if len(route.Tags) > 0 && !anyEqual(route.Tags, matched.Job.Skills, true) {
+		return false
+	}
+	return true
+}
+
+// anyEqual reports whether a and b share a value
+func anyEqual(a, b []string, foldCase bool) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y || foldCase && strings.EqualFold(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+	"jobradar/internal/notifier"
+	"jobradar/internal/storage"
+)
+
+func TestRouteChannels(t *testing.T) {
+	budget := func(v float64) *float64 { return &v }
+	golang := &model.MatchedJob{
+		Job:        &model.Job{ID: "~01", JobType: model.JobTypeFixed, BudgetMax: budget(2000), Skills: []string{"Go", "PostgreSQL"}},
+		MatchScore: 0.9,
+		Matches:    []model.SearchMatch{{SearchName: "Golang API", MatchScore: 0.9}},
+	}
+	script := &model.MatchedJob{
+		Job:        &model.Job{ID: "~02", JobType: model.JobTypeHourly, Skills: []string{"Python"}},
+		MatchScore: 1,
+		Matches:    []model.SearchMatch{{SearchName: "Quick scripts", MatchScore: 1}},
+	}
+
+	routes := []config.RouteConfig{
+		{Searches: []string{"Quick scripts"}, Channels: []string{"slack"}, Final: true},
+		{Searches: []string{"Golang API"}, Channels: []string{"telegram:a"}},
+		{MinScore: 0.8, Channels: []string{"email"}},
+		{MinBudget: 1000, Channels: []string{"email:boss"}},
+		{Tags: []string{"postgresql"}, Channels: []string{"discord"}},
+	}
+
+	tests := []struct {
+		name    string
+		routes  []config.RouteConfig
+		matched *model.MatchedJob
+		want    []string // nil for every channel
+	}{
+		{"no routes", nil, golang, nil},
+		{"union of matching routes", routes, golang, []string{"discord", "email", "email:boss", "telegram:a"}},
+		{"final route stops", routes, script, []string{"slack"}},
+		{"no budget never matches budget", routes[3:], script, []string{}},
+		{"max budget", []config.RouteConfig{{MaxBudget: 500, Channels: []string{"slack"}}}, golang, []string{}},
+		{"catch-all", []config.RouteConfig{{Channels: []string{"telegram"}}}, script, []string{"telegram"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channels := routeChannels(tt.routes, tt.matched)
+			if tt.want == nil {
+				if channels != nil {
+					t.Errorf("routeChannels() = %v, want every channel", channels)
+				}
+				return
+			}
+			got := []string{}
+			for name := range channels {
+				got = append(got, name)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("routeChannels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngine_EnqueueRoutes(t *testing.T) {
+	store, err := storage.NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	defer store.Close()
+
+	telegram := &flakyNotifier{name: "telegram"}
+	team := &flakyNotifier{name: "telegram:team"}
+	slack := &flakyNotifier{name: "slack"}
+	cfg := &config.AppConfig{
+		Notifications: config.NotificationConfig{
+			Routes: []config.RouteConfig{
+				{Searches: []string{"Golang"}, Channels: []string{"telegram:team"}},
+			},
+			Retry: config.RetryConfig{MaxAttempts: 3, BackoffMinutes: 5, ExpireHours: 24},
+		},
+	}
+	e := &Engine{config: cfg, storage: store, notifiers: []notifier.Notifier{telegram, team, slack}}
+
+	now := time.Now()
+	matched := model.NewMatchedJob(&model.Job{ID: "~01a", Title: "Go API"}, []string{"golang"}, "Golang", 1)
+	if queued, err := e.enqueue(matched, now); err != nil || queued != 1 {
+		t.Fatalf("enqueue() = %d, %v, want 1 channel", queued, err)
+	}
+	other := model.NewMatchedJob(&model.Job{ID: "~01b", Title: "Scraper"}, []string{"python"}, "Python", 1)
+	if queued, err := e.enqueue(other, now); err != nil || queued != 0 {
+		t.Fatalf("enqueue() = %d, %v, want no channel", queued, err)
+	}
+
+	e.deliver(now)
+	if len(team.sent) != 1 || len(telegram.sent) != 0 || len(slack.sent) != 0 {
+		t.Errorf("sent telegram=%v team=%v slack=%v, want only telegram:team", telegram.sent, team.sent, slack.sent)
+	}
+}
@@ -0,0 +1,206 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatXLSX   Format = "xlsx" // CSV tuned for spreadsheet applications
+)
+
+// Formats lists the supported formats
+var Formats = []Format{FormatCSV, FormatJSON, FormatNDJSON, FormatXLSX}
+
+// maxCellLength is the longest text a spreadsheet cell can hold
+const maxCellLength = 32767
+
+// ParseFormat validates a format name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if strings.EqualFold(name, string(f)) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown export format %q (supported: csv, json, ndjson, xlsx)", name)
+}
+
+// Row is a single exported record. Value is encoded as-is for JSON formats;
+// Fields are its flattened columns in header order for CSV formats.
+type Row struct {
+	Value  interface{}
+	Fields []interface{}
+}
+
+// Writer streams rows to an output in one format
+type Writer struct {
+	out    io.Writer
+	format Format
+	csv    *csv.Writer
+	rows   int
+}
+
+// NewWriter creates a writer and emits any preamble, such as the CSV header
+func NewWriter(out io.Writer, format Format, header []string) (*Writer, error) {
+	w := &Writer{out: out, format: format}
+
+	switch format {
+	case FormatCSV, FormatXLSX:
+		if format == FormatXLSX {
+			// A byte order mark makes spreadsheets read the file as UTF-8
+			if _, err := io.WriteString(out, "\ufeff"); err != nil {
+				return nil, err
+			}
+		}
+		w.csv = csv.NewWriter(out)
+		w.csv.UseCRLF = format == FormatXLSX
+		if err := w.csv.Write(header); err != nil {
+			return nil, err
+		}
+	case FormatJSON:
+		if _, err := io.WriteString(out, "["); err != nil {
+			return nil, err
+		}
+	case FormatNDJSON:
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+
+	return w, nil
+}
+
+// Write writes a single row
+func (w *Writer) Write(row Row) error {
+	w.rows++
+
+	switch w.format {
+	case FormatCSV, FormatXLSX:
+		record := make([]string, len(row.Fields))
+		for i, v := range row.Fields {
+			record[i] = w.formatField(v)
+		}
+		return w.csv.Write(record)
+
+	case FormatJSON:
+		data, err := json.MarshalIndent(row.Value, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		sep := ",\n  "
+		if w.rows == 1 {
+			sep = "\n  "
+		}
+		_, err = io.WriteString(w.out, sep+string(data))
+		return err
+
+	default:
+		data, err := json.Marshal(row.Value)
+		if err != nil {
+			return err
+		}
+		_, err = w.out.Write(append(data, '\n'))
+		return err
+	}
+}
+
+// Close flushes buffered output and closes any open structure
+func (w *Writer) Close() error {
+	switch w.format {
+	case FormatCSV, FormatXLSX:
+		w.csv.Flush()
+		return w.csv.Error()
+	case FormatJSON:
+		end := "\n]\n"
+		if w.rows == 0 {
+			end = "]\n"
+		}
+		_, err := io.WriteString(w.out, end)
+		return err
+	}
+	return nil
+}
+
+// Rows reports how many rows have been written
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// formatField renders a field for CSV output. Spreadsheet output uses local
+// times without a zone, which spreadsheets recognise as dates, and guards
+// text that would otherwise be evaluated as a formula.
+func (w *Writer) formatField(v interface{}) string {
+	excel := w.format == FormatXLSX
+
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if excel {
+			return spreadsheetText(v)
+		}
+		return v
+	case []string:
+		return w.formatField(strings.Join(v, "; "))
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return w.formatField(*v)
+	case *int:
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		if excel {
+			return v.Local().Format("2006-01-02 15:04:05")
+		}
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return w.formatField(*v)
+	case fmt.Stringer:
+		return w.formatField(v.String())
+	default:
+		return w.formatField(fmt.Sprint(v))
+	}
+}
+
+// spreadsheetText truncates text to fit a cell and prefixes values that
+// spreadsheets would treat as formulas
+func spreadsheetText(s string) string {
+	if len(s) > maxCellLength {
+		runes := []rune(s)
+		if len(runes) > maxCellLength {
+			s = string(runes[:maxCellLength])
+		}
+	}
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		s = "'" + s
+	}
+	return s
+}
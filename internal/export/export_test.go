@@ -0,0 +1,114 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+func testRecords() []*model.NotifyRecord {
+	sent := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	return []*model.NotifyRecord{
+		{ID: 1, JobID: "~01a", JobTitle: "Go, \"urgent\"", SearchName: "Golang API",
+			NotifyChannel: "telegram", Status: model.NotifyStatusSent, CreatedAt: sent, SentAt: &sent},
+		{ID: 2, JobID: "~01b", JobTitle: "=HYPERLINK(\"x\")", NotifyChannel: "email",
+			Status: model.NotifyStatusFailed, ErrorMessage: "timeout", CreatedAt: sent},
+	}
+}
+
+func writeAll(t *testing.T, format Format) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, format, NotifyRecordHeader)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	for _, r := range testRecords() {
+		if err := w.Write(NotifyRecordRow(r)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestWriter_CSV(t *testing.T) {
+	out := writeAll(t, FormatCSV)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want header + 2 rows:\n%s", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[0], "id,created_at,sent_at,job_id") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if !strings.Contains(lines[1], `"Go, ""urgent"""`) {
+		t.Errorf("title not quoted correctly: %q", lines[1])
+	}
+	if !strings.Contains(lines[1], "2024-03-01T09:30:00Z") {
+		t.Errorf("time not in RFC 3339: %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "=HYPERLINK") || strings.Contains(lines[2], "'=HYPERLINK") {
+		t.Errorf("plain CSV should not alter values: %q", lines[2])
+	}
+}
+
+func TestWriter_XLSX(t *testing.T) {
+	out := writeAll(t, FormatXLSX)
+
+	if !strings.HasPrefix(out, "\ufeff") {
+		t.Error("spreadsheet CSV should start with a byte order mark")
+	}
+	if !strings.Contains(out, "\r\n") {
+		t.Error("spreadsheet CSV should use CRLF line endings")
+	}
+	if !strings.Contains(out, `"'=HYPERLINK(""x"")"`) {
+		t.Errorf("formula was not escaped:\n%s", out)
+	}
+}
+
+func TestWriter_JSON(t *testing.T) {
+	var records []model.NotifyRecord
+	if err := json.Unmarshal([]byte(writeAll(t, FormatJSON)), &records); err != nil {
+		t.Fatalf("output is not a JSON array: %v", err)
+	}
+	if len(records) != 2 || records[1].ErrorMessage != "timeout" {
+		t.Errorf("unexpected records %+v", records)
+	}
+
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, FormatJSON, nil)
+	w.Close()
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("empty export = %q, want []", buf.String())
+	}
+}
+
+func TestWriter_NDJSON(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(writeAll(t, FormatNDJSON)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	for _, line := range lines {
+		var r model.NotifyRecord
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Errorf("line %q is not JSON: %v", line, err)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("NDJSON"); err != nil || f != FormatNDJSON {
+		t.Errorf("ParseFormat(NDJSON) = %v, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) should fail")
+	}
+}
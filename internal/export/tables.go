@@ -0,0 +1,87 @@
+package export
+
+import (
+	"strings"
+
+	"jobradar/internal/model"
+)
+
+// NotifyRecordHeader lists the CSV columns of notification records
+var NotifyRecordHeader = []string{
+	"id", "created_at", "sent_at", "job_id", "job_title", "job_url",
+	"search_name", "matched_keywords", "channel", "status", "attempts", "error_message",
+}
+
+// NotifyRecordRow converts a notification record into a row
+func NotifyRecordRow(r *model.NotifyRecord) Row {
+	return Row{
+		Value: r,
+		Fields: []interface{}{
+			r.ID, r.CreatedAt, r.SentAt, r.JobID, r.JobTitle, r.JobURL,
+			r.SearchName, r.MatchedKeywords, r.NotifyChannel, string(r.Status), r.Attempts, r.ErrorMessage,
+		},
+	}
+}
+
+// RunLogHeader lists the CSV columns of run logs
+var RunLogHeader = []string{
+	"id", "started_at", "finished_at", "duration_seconds", "jobs_fetched",
+	"jobs_matched", "jobs_notified", "jobs_skipped", "error_message",
+}
+
+// RunLogRow converts a run log into a row
+func RunLogRow(r *model.RunStats) Row {
+	return Row{
+		Value: r,
+		Fields: []interface{}{
+			r.ID, r.StartedAt, r.FinishedAt, r.DurationSeconds, r.JobsFetched,
+			r.JobsMatched, r.JobsNotified, r.JobsSkipped, r.ErrorMessage,
+		},
+	}
+}
+
+// JobHeader lists the CSV columns of stored jobs
+var JobHeader = []string{
+	"id", "title", "url", "job_type", "budget", "budget_min", "budget_max",
+	"hourly_rate_min", "hourly_rate_max", "proposals", "client_country",
+	"client_rating", "client_total_spent", "client_total_hires", "language",
+	"skills", "searches", "matched_searches", "matched_keywords", "match_score",
+	"posted_at", "first_seen_at", "last_seen_at", "description",
+}
+
+// JobRow converts a stored job into a row
+func JobRow(r *model.JobRecord) Row {
+	job := r.Job
+
+	var matched, keywords []string
+	for _, m := range r.Matches {
+		matched = append(matched, m.SearchName)
+		keywords = append(keywords, m.MatchedKeywords...)
+	}
+
+	return Row{
+		Value: r,
+		Fields: []interface{}{
+			job.ID, job.Title, job.URL, string(job.JobType), job.BudgetDisplay(),
+			job.BudgetMin, job.BudgetMax, job.HourlyRateMin, job.HourlyRateMax,
+			job.Proposals, job.ClientCountry, job.ClientRating,
+			job.ClientTotalSpent, job.ClientTotalHires, job.Language,
+			job.Skills, r.Searches, matched, dedupe(keywords), r.MatchScore,
+			job.PostedAt, r.FirstSeenAt, r.LastSeenAt, job.Description,
+		},
+	}
+}
+
+// dedupe removes case-insensitive duplicates, keeping the first spelling
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var result []string
+	for _, v := range values {
+		key := strings.ToLower(v)
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxErrorBody limits how much of an error response body is kept
+const maxErrorBody = 200
+
+// HTTPError is returned when a source answers with a status other than 200 OK
+type HTTPError struct {
+	Source     string // What was fetched, e.g. "API" or "RSS feed"
+	StatusCode int
+	Body       string // Start of the response body, if any
+}
+
+func (e *HTTPError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s returned status %d", e.Source, e.StatusCode)
+	}
+	return fmt.Sprintf("%s returned status %d: %s", e.Source, e.StatusCode, e.Body)
+}
+
+// newHTTPError builds an HTTPError, trimming a long response body
+func newHTTPError(source string, statusCode int, body []byte) *HTTPError {
+	if len(body) > maxErrorBody {
+		body = append(body[:maxErrorBody:maxErrorBody], "..."...)
+	}
+	return &HTTPError{Source: source, StatusCode: statusCode, Body: string(body)}
+}
+
+// StatusCode returns the HTTP status carried by err, or 0 if the request
+// failed without a response
+func StatusCode(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+	return 0
+}
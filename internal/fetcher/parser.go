@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"jobradar/internal/langdetect"
 	"jobradar/internal/model"
 
 	"github.com/mmcdole/gofeed"
@@ -46,10 +47,13 @@ func ParseRSSItem(item *gofeed.Item) *model.Job {
 		postedAt = *pubDate
 	}
 
+	cleanTitle := cleanText(title)
+	cleanDesc := cleanDescription(description)
+
 	return &model.Job{
 		ID:            jobID,
-		Title:         cleanText(title),
-		Description:   cleanDescription(description),
+		Title:         cleanTitle,
+		Description:   cleanDesc,
 		URL:           link,
 		JobType:       jobType,
 		BudgetMin:     budgetMin,
@@ -59,6 +63,7 @@ func ParseRSSItem(item *gofeed.Item) *model.Job {
 		Proposals:     proposals,
 		ClientCountry: country,
 		Skills:        skills,
+		Language:      langdetect.Detect(cleanTitle + " " + cleanDesc),
 		PostedAt:      postedAt,
 		FetchedAt:     time.Now(),
 	}
@@ -41,7 +41,7 @@ func (f *RSSFetcher) FetchFromURL(feedURL string) ([]*model.Job, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("RSS fetch failed with status %d", resp.StatusCode)
+		return nil, newHTTPError("RSS feed", resp.StatusCode, nil)
 	}
 
 	feed, err := f.parser.Parse(resp.Body)
@@ -0,0 +1,24 @@
+package fetcher
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchFromURL_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewRSSFetcher().FetchFromURL(server.URL)
+	if got := StatusCode(err); got != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode() = %d, want %d (err = %v)", got, http.StatusServiceUnavailable, err)
+	}
+
+	if got := StatusCode(errors.New("connection refused")); got != 0 {
+		t.Errorf("StatusCode() of a transport error = %d, want 0", got)
+	}
+}
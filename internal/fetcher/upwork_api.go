@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"time"
 
+	"jobradar/internal/langdetect"
 	"jobradar/internal/model"
 
 	"github.com/rs/zerolog/log"
@@ -186,7 +187,7 @@ func (f *UpworkAPIFetcher) FetchJobs(searchTerm string, limit int) ([]*model.Job
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, newHTTPError("API", resp.StatusCode, body)
 	}
 
 	var gqlResp graphQLResponse
@@ -225,6 +226,7 @@ func convertToJob(node jobNode) *model.Job {
 		Title:       node.Title,
 		Description: node.Description,
 		URL:         buildJobURL(node.CipherText),
+		Language:    langdetect.Detect(node.Title + " " + node.Description),
 		FetchedAt:   time.Now(),
 	}
 
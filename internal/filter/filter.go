@@ -23,6 +23,12 @@ func New(cfg config.FilterConfig) *Filter {
 // Match checks if a job matches the filter criteria
 // Returns matched keywords if the job passes all filters, nil otherwise
 func (f *Filter) Match(job *model.Job, keywords []string) []string {
+	return f.MatchAt(job, keywords, time.Now())
+}
+
+// MatchAt checks a job as Match would have at the given time, so stored
+// jobs can be replayed against the posted time window they were fetched in
+func (f *Filter) MatchAt(job *model.Job, keywords []string, now time.Time) []string {
 	// 1. Check exclude keywords
 	if f.hasExcludeKeywords(job) {
 		log.Debug().Str("job", job.ID).Msg("Excluded by keywords")
@@ -48,12 +54,18 @@ func (f *Filter) Match(job *model.Job, keywords []string) []string {
 	}
 
 	// 5. Check posted time
-	if !f.checkPostedTime(job) {
+	if !f.checkPostedTime(job, now) {
 		log.Debug().Str("job", job.ID).Msg("Excluded by posted time")
 		return nil
 	}
 
-	// 6. Check keyword match
+	// 6. Check language
+	if !f.checkLanguage(job) {
+		log.Debug().Str("job", job.ID).Str("language", job.Language).Msg("Excluded by language")
+		return nil
+	}
+
+	// 7. Check keyword match
 	matched := f.matchKeywords(job, keywords)
 	if len(matched) == 0 {
 		log.Debug().Str("job", job.ID).Msg("No keyword match")
@@ -79,26 +91,10 @@ func (f *Filter) hasExcludeKeywords(job *model.Job) bool {
 
 // checkBudget verifies the job budget is within range
 func (f *Filter) checkBudget(job *model.Job) bool {
-	var budget float64
-
-	if job.JobType == model.JobTypeFixed {
-		if job.BudgetMax != nil {
-			budget = *job.BudgetMax
-		} else if job.BudgetMin != nil {
-			budget = *job.BudgetMin
-		} else {
-			// No budget info, allow by default
-			return true
-		}
-	} else {
-		// For hourly, check hourly rate
-		if job.HourlyRateMax != nil {
-			budget = *job.HourlyRateMax
-		} else if job.HourlyRateMin != nil {
-			budget = *job.HourlyRateMin
-		} else {
-			return true
-		}
+	budget, ok := job.Budget()
+	if !ok {
+		// No budget info, allow by default
+		return true
 	}
 
 	if budget < float64(f.config.Budget.Min) {
@@ -144,15 +140,48 @@ func (f *Filter) checkProposals(job *model.Job) bool {
 }
 
 // checkPostedTime verifies the job was posted within the configured time window
-func (f *Filter) checkPostedTime(job *model.Job) bool {
+func (f *Filter) checkPostedTime(job *model.Job, now time.Time) bool {
 	if f.config.PostedWithinHours <= 0 {
 		return true
 	}
 
-	cutoff := time.Now().Add(-time.Duration(f.config.PostedWithinHours) * time.Hour)
+	cutoff := now.Add(-time.Duration(f.config.PostedWithinHours) * time.Hour)
 	return job.PostedAt.After(cutoff)
 }
 
+// Score rates how well a job matched a search as the fraction of the
+// search keywords that were found
+func Score(matched, keywords []string) float64 {
+	if len(keywords) == 0 {
+		return 0
+	}
+	score := float64(len(matched)) / float64(len(keywords))
+	if score > 1 {
+		score = 1
+	}
+	return score
+}
+
+// checkLanguage verifies the detected job language is in the allow list
+func (f *Filter) checkLanguage(job *model.Job) bool {
+	if len(f.config.Languages) == 0 {
+		return true
+	}
+
+	if job.Language == "" {
+		// Language could not be detected, allow by default
+		return true
+	}
+
+	for _, lang := range f.config.Languages {
+		if strings.EqualFold(lang, job.Language) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // matchKeywords finds matching keywords in job title and description
 func (f *Filter) matchKeywords(job *model.Job, keywords []string) []string {
 	text := strings.ToLower(job.Title + " " + job.Description)
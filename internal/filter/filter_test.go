@@ -346,6 +346,70 @@ func TestFilter_Match_PostedTime(t *testing.T) {
 	}
 }
 
+func TestFilter_MatchAt_PostedTime(t *testing.T) {
+	cfg := config.FilterConfig{
+		Budget:            config.BudgetFilter{Min: 0, Max: 100000},
+		JobType:           config.JobTypeAll,
+		PostedWithinHours: 24,
+	}
+	f := New(cfg)
+
+	fetchedAt := time.Now().AddDate(0, 0, -5)
+	job := &model.Job{
+		ID:          "1",
+		Title:       "Golang Developer",
+		Description: "Need golang developer",
+		JobType:     model.JobTypeFixed,
+		PostedAt:    fetchedAt.Add(-2 * time.Hour),
+	}
+
+	if len(f.MatchAt(job, []string{"golang"}, fetchedAt)) == 0 {
+		t.Error("MatchAt() at fetch time should match a job posted 2 hours earlier")
+	}
+	if len(f.Match(job, []string{"golang"})) > 0 {
+		t.Error("Match() now should not match a job posted 5 days ago")
+	}
+}
+
+func TestFilter_Match_Language(t *testing.T) {
+	cfg := config.FilterConfig{
+		Budget:    config.BudgetFilter{Min: 0, Max: 100000},
+		JobType:   config.JobTypeAll,
+		Languages: []string{"en", "DE"},
+	}
+	f := New(cfg)
+
+	tests := []struct {
+		name     string
+		language string
+		want     bool
+	}{
+		{"english should match", "en", true},
+		{"german should match case-insensitively", "de", true},
+		{"french should not match", "fr", false},
+		{"undetected language should match", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &model.Job{
+				ID:          "1",
+				Title:       "Golang Developer",
+				Description: "Need golang developer",
+				JobType:     model.JobTypeFixed,
+				Language:    tt.language,
+				PostedAt:    time.Now(),
+			}
+
+			result := f.Match(job, []string{"golang"})
+			got := len(result) > 0
+			if got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // Helper functions
 func floatPtr(f float64) *float64 {
 	return &f
@@ -354,3 +418,21 @@ func floatPtr(f float64) *float64 {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		matched  []string
+		keywords []string
+		want     float64
+	}{
+		{[]string{"golang"}, []string{"golang", "api"}, 0.5},
+		{[]string{"golang", "api"}, []string{"golang", "api"}, 1},
+		{[]string{"golang", "api"}, []string{"golang"}, 1},
+		{nil, nil, 0},
+	}
+	for _, tt := range tests {
+		if got := Score(tt.matched, tt.keywords); got != tt.want {
+			t.Errorf("Score(%v, %v) = %v, want %v", tt.matched, tt.keywords, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package langdetect
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// profileSize is the number of ranked trigrams kept per language
+	profileSize = 300
+
+	// minLetters is the minimum number of letters needed for a guess
+	minLetters = 20
+)
+
+// languageNames maps ISO 639-1 codes to display names
+var languageNames = map[string]string{
+	"en": "English",
+	"de": "German",
+	"fr": "French",
+	"es": "Spanish",
+	"it": "Italian",
+	"pt": "Portuguese",
+	"nl": "Dutch",
+	"pl": "Polish",
+	"ru": "Russian",
+	"uk": "Ukrainian",
+	"el": "Greek",
+	"ar": "Arabic",
+	"he": "Hebrew",
+	"hi": "Hindi",
+	"th": "Thai",
+	"zh": "Chinese",
+	"ja": "Japanese",
+	"ko": "Korean",
+}
+
+// profiles holds the ranked trigram profile of each Latin-script language
+var profiles = buildProfiles()
+
+// Detect returns the ISO 639-1 code of the language the text is written in.
+// An empty string is returned when the text is too short or the language
+// cannot be determined.
+func Detect(text string) string {
+	counts := scriptCounts(text)
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	if total == 0 {
+		return ""
+	}
+
+	// Pick the dominant script; only Latin needs n-gram analysis
+	script, best := "", 0
+	for s, n := range counts {
+		if n > best || (n == best && s < script) {
+			script, best = s, n
+		}
+	}
+
+	switch script {
+	case "latin":
+		if counts["latin"] < minLetters {
+			return ""
+		}
+		return detectLatin(text)
+	case "cyrillic":
+		if strings.ContainsAny(strings.ToLower(text), "їєіґ") {
+			return "uk"
+		}
+		return "ru"
+	case "han":
+		// Japanese text mixes kanji with kana
+		if counts["kana"] > 0 {
+			return "ja"
+		}
+		return "zh"
+	case "kana":
+		return "ja"
+	case "hangul":
+		return "ko"
+	case "greek":
+		return "el"
+	case "arabic":
+		return "ar"
+	case "hebrew":
+		return "he"
+	case "devanagari":
+		return "hi"
+	case "thai":
+		return "th"
+	}
+	return ""
+}
+
+// Name returns the display name of a language code, or the code itself if unknown
+func Name(code string) string {
+	if name, ok := languageNames[strings.ToLower(code)]; ok {
+		return name
+	}
+	return code
+}
+
+// IsSupported reports whether the language code can be returned by Detect
+func IsSupported(code string) bool {
+	_, ok := languageNames[strings.ToLower(code)]
+	return ok
+}
+
+// Supported returns all language codes that Detect can return, sorted
+func Supported() []string {
+	codes := make([]string, 0, len(languageNames))
+	for code := range languageNames {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// scriptCounts counts letters per writing system
+func scriptCounts(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["cyrillic"]++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			counts["kana"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["hangul"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["greek"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["arabic"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["hebrew"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["devanagari"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["thai"]++
+		}
+	}
+	return counts
+}
+
+// detectLatin picks the Latin-script language whose profile is closest
+// to the text, using the Cavnar-Trenkle out-of-place distance
+func detectLatin(text string) string {
+	doc := rankTrigrams(text)
+	if len(doc) == 0 {
+		return ""
+	}
+
+	bestLang, bestDist := "", -1
+	for lang, profile := range profiles {
+		dist := 0
+		for gram, rank := range doc {
+			if pr, ok := profile[gram]; ok {
+				if pr > rank {
+					dist += pr - rank
+				} else {
+					dist += rank - pr
+				}
+			} else {
+				dist += profileSize
+			}
+		}
+		if bestDist < 0 || dist < bestDist || (dist == bestDist && lang < bestLang) {
+			bestLang, bestDist = lang, dist
+		}
+	}
+	return bestLang
+}
+
+// buildProfiles computes trigram profiles from the training samples
+func buildProfiles() map[string]map[string]int {
+	result := make(map[string]map[string]int, len(samples))
+	for lang, sample := range samples {
+		result[lang] = rankTrigrams(sample)
+	}
+	return result
+}
+
+// rankTrigrams returns the most frequent trigrams of the text mapped to their rank
+func rankTrigrams(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.Is(unicode.Latin, r)
+	}) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+
+	grams := make([]string, 0, len(counts))
+	for gram := range counts {
+		grams = append(grams, gram)
+	}
+	sort.Slice(grams, func(i, j int) bool {
+		if counts[grams[i]] != counts[grams[j]] {
+			return counts[grams[i]] > counts[grams[j]]
+		}
+		return grams[i] < grams[j]
+	})
+	if len(grams) > profileSize {
+		grams = grams[:profileSize]
+	}
+
+	ranks := make(map[string]int, len(grams))
+	for i, gram := range grams {
+		ranks[gram] = i
+	}
+	return ranks
+}
@@ -0,0 +1,94 @@
+package langdetect
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "english posting",
+			text: "Golang developer needed to build a REST API for our inventory management system. Must have experience with PostgreSQL.",
+			want: "en",
+		},
+		{
+			name: "german posting",
+			text: "Wir suchen einen Golang Entwickler für die Erweiterung unserer bestehenden REST API. Erfahrung mit PostgreSQL ist erforderlich.",
+			want: "de",
+		},
+		{
+			name: "french posting",
+			text: "Nous cherchons un développeur Golang pour créer une API REST pour notre système de gestion des stocks.",
+			want: "fr",
+		},
+		{
+			name: "spanish posting",
+			text: "Buscamos un desarrollador Golang para crear una API REST para nuestro sistema de gestión de inventario.",
+			want: "es",
+		},
+		{
+			name: "portuguese posting",
+			text: "Procuramos um desenvolvedor Golang para criar uma API REST para o nosso sistema de gestão de estoque.",
+			want: "pt",
+		},
+		{
+			name: "dutch posting",
+			text: "Wij zoeken een Golang ontwikkelaar om een REST API te bouwen voor ons voorraadbeheersysteem.",
+			want: "nl",
+		},
+		{
+			name: "russian posting",
+			text: "Ищем разработчика на Go для создания REST API для нашей системы управления складом.",
+			want: "ru",
+		},
+		{
+			name: "japanese posting",
+			text: "在庫管理システムのためのREST APIを作成できるGo開発者を探しています。",
+			want: "ja",
+		},
+		{
+			name: "chinese posting",
+			text: "我们正在寻找一名Go开发人员为我们的库存管理系统构建接口。",
+			want: "zh",
+		},
+		{
+			name: "too short",
+			text: "Go dev",
+			want: "",
+		},
+		{
+			name: "empty",
+			text: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(tt.text)
+			if got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	if got := Name("de"); got != "German" {
+		t.Errorf("Name(de) = %v, want German", got)
+	}
+	if got := Name("xx"); got != "xx" {
+		t.Errorf("Name(xx) = %v, want xx", got)
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	if !IsSupported("EN") {
+		t.Error("IsSupported(EN) = false, want true")
+	}
+	if IsSupported("xx") {
+		t.Error("IsSupported(xx) = true, want false")
+	}
+}
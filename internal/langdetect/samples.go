@@ -0,0 +1,133 @@
+package langdetect
+
+// samples holds the training text used to build the Latin-script profiles.
+// Each sample mixes everyday prose with the vocabulary of job postings so
+// the profiles reflect the text JobRadar actually sees.
+var samples = map[string]string{
+	"en": `We are looking for an experienced developer to join our small team and help us build
+the next version of our platform. The ideal candidate has strong knowledge of backend development,
+writes clean and well tested code, and is comfortable working with a remote team across different
+time zones. You will be responsible for designing new features, fixing bugs and improving the
+performance of the existing system. Please include examples of similar projects you have worked on
+in your proposal, and tell us how long you think this would take. This is a long term project with
+the possibility of ongoing work for the right person. Our company was founded a few years ago and
+has grown quickly since then. We value clear communication, honest estimates and people who take
+ownership of their work. If you have any questions about the requirements, feel free to ask before
+you submit your application. The budget is flexible depending on experience. We would like to start
+as soon as possible and expect regular updates throughout the week. The weather was nice yesterday,
+so they went for a walk in the park with their children and talked about what they should do next
+summer. There is nothing more important than the trust between the client and the freelancer, and
+that is why we want to work with someone who will stay with us for a while.`,
+
+	"de": `Wir suchen einen erfahrenen Entwickler, der unser kleines Team unterstützt und uns bei der
+nächsten Version unserer Plattform hilft. Der ideale Kandidat hat fundierte Kenntnisse in der
+Backend-Entwicklung, schreibt sauberen und gut getesteten Code und arbeitet gerne mit einem Team
+zusammen, das über verschiedene Zeitzonen verteilt ist. Sie sind für die Entwicklung neuer Funktionen,
+die Behebung von Fehlern und die Verbesserung der Leistung des bestehenden Systems verantwortlich.
+Bitte fügen Sie Ihrem Angebot Beispiele ähnlicher Projekte bei, an denen Sie gearbeitet haben, und
+teilen Sie uns mit, wie lange die Umsetzung dauern würde. Es handelt sich um ein langfristiges Projekt
+mit der Möglichkeit einer dauerhaften Zusammenarbeit. Unser Unternehmen wurde vor einigen Jahren
+gegründet und ist seitdem schnell gewachsen. Wir legen Wert auf klare Kommunikation, ehrliche
+Schätzungen und Menschen, die Verantwortung für ihre Arbeit übernehmen. Wenn Sie Fragen zu den
+Anforderungen haben, können Sie diese gerne vor Ihrer Bewerbung stellen. Das Budget ist je nach
+Erfahrung verhandelbar. Wir möchten so schnell wie möglich beginnen und erwarten regelmäßige
+Rückmeldungen während der Woche. Gestern war das Wetter schön, deshalb sind sie mit ihren Kindern im
+Park spazieren gegangen und haben darüber gesprochen, was sie im nächsten Sommer machen wollen. Nichts
+ist wichtiger als das Vertrauen zwischen dem Auftraggeber und dem Freiberufler.`,
+
+	"fr": `Nous recherchons un développeur expérimenté pour rejoindre notre petite équipe et nous aider
+à construire la prochaine version de notre plateforme. Le candidat idéal possède une solide
+connaissance du développement backend, écrit un code propre et bien testé, et est à l'aise pour
+travailler avec une équipe répartie sur différents fuseaux horaires. Vous serez responsable de la
+conception de nouvelles fonctionnalités, de la correction des bogues et de l'amélioration des
+performances du système existant. Merci d'inclure dans votre proposition des exemples de projets
+similaires sur lesquels vous avez travaillé, et de nous indiquer combien de temps cela prendrait. Il
+s'agit d'un projet à long terme avec la possibilité d'une collaboration durable pour la bonne
+personne. Notre entreprise a été fondée il y a quelques années et elle a grandi rapidement depuis.
+Nous accordons de l'importance à une communication claire, à des estimations honnêtes et aux
+personnes qui prennent leur travail en main. Si vous avez des questions sur les exigences, n'hésitez
+pas à les poser avant de soumettre votre candidature. Le budget est flexible selon l'expérience. Nous
+aimerions commencer dès que possible et attendons des nouvelles régulières pendant la semaine. Hier il
+faisait beau, alors ils sont allés se promener dans le parc avec leurs enfants et ont parlé de ce
+qu'ils feraient l'été prochain. Rien n'est plus important que la confiance entre le client et le
+travailleur indépendant.`,
+
+	"es": `Estamos buscando un desarrollador con experiencia para unirse a nuestro pequeño equipo y
+ayudarnos a construir la próxima versión de nuestra plataforma. El candidato ideal tiene un sólido
+conocimiento del desarrollo backend, escribe código limpio y bien probado, y se siente cómodo
+trabajando con un equipo remoto en diferentes zonas horarias. Usted será responsable de diseñar nuevas
+funciones, corregir errores y mejorar el rendimiento del sistema existente. Por favor incluya en su
+propuesta ejemplos de proyectos similares en los que haya trabajado, y díganos cuánto tiempo cree que
+llevaría. Se trata de un proyecto a largo plazo con la posibilidad de trabajo continuo para la persona
+adecuada. Nuestra empresa fue fundada hace algunos años y ha crecido rápidamente desde entonces.
+Valoramos la comunicación clara, las estimaciones honestas y las personas que se hacen cargo de su
+trabajo. Si tiene alguna pregunta sobre los requisitos, no dude en consultarnos antes de enviar su
+solicitud. El presupuesto es flexible según la experiencia. Nos gustaría empezar lo antes posible y
+esperamos actualizaciones periódicas durante la semana. Ayer hacía buen tiempo, así que fueron a
+caminar por el parque con sus hijos y hablaron de lo que harían el próximo verano. No hay nada más
+importante que la confianza entre el cliente y el trabajador independiente, y por eso queremos
+trabajar con alguien que se quede con nosotros durante mucho tiempo.`,
+
+	"it": `Stiamo cercando uno sviluppatore esperto che si unisca al nostro piccolo gruppo e ci aiuti a
+costruire la prossima versione della nostra piattaforma. Il candidato ideale ha una solida conoscenza
+dello sviluppo backend, scrive codice pulito e ben testato, e si trova a suo agio nel lavorare con una
+squadra distribuita su diversi fusi orari. Sarai responsabile della progettazione di nuove
+funzionalità, della correzione degli errori e del miglioramento delle prestazioni del sistema
+esistente. Per favore includi nella tua proposta esempi di progetti simili a cui hai lavorato, e
+dicci quanto tempo pensi che ci vorrebbe. Si tratta di un progetto a lungo termine con la possibilità
+di una collaborazione continua per la persona giusta. La nostra azienda è stata fondata alcuni anni fa
+ed è cresciuta rapidamente da allora. Diamo valore a una comunicazione chiara, a stime oneste e alle
+persone che si prendono la responsabilità del proprio lavoro. Se hai domande sui requisiti, non
+esitare a chiedere prima di inviare la tua candidatura. Il budget è flessibile in base
+all'esperienza. Vorremmo iniziare il prima possibile e ci aspettiamo aggiornamenti regolari durante
+la settimana. Ieri il tempo era bello, quindi sono andati a passeggiare nel parco con i loro figli e
+hanno parlato di cosa fare la prossima estate. Non c'è niente di più importante della fiducia tra il
+cliente e il libero professionista.`,
+
+	"pt": `Estamos procurando um desenvolvedor experiente para se juntar à nossa pequena equipe e nos
+ajudar a construir a próxima versão da nossa plataforma. O candidato ideal tem um sólido conhecimento
+de desenvolvimento backend, escreve código limpo e bem testado, e se sente confortável trabalhando com
+uma equipe remota em diferentes fusos horários. Você será responsável por projetar novas
+funcionalidades, corrigir erros e melhorar o desempenho do sistema existente. Por favor, inclua na sua
+proposta exemplos de projetos semelhantes em que você trabalhou, e nos diga quanto tempo acha que
+levaria. Trata-se de um projeto de longo prazo com a possibilidade de trabalho contínuo para a pessoa
+certa. Nossa empresa foi fundada há alguns anos e cresceu rapidamente desde então. Valorizamos a
+comunicação clara, estimativas honestas e pessoas que assumem a responsabilidade pelo seu trabalho.
+Se você tiver alguma dúvida sobre os requisitos, não hesite em perguntar antes de enviar a sua
+candidatura. O orçamento é flexível de acordo com a experiência. Gostaríamos de começar o mais rápido
+possível e esperamos atualizações regulares durante a semana. Ontem o tempo estava bom, então eles
+foram passear no parque com os seus filhos e conversaram sobre o que fariam no próximo verão. Não há
+nada mais importante do que a confiança entre o cliente e o profissional autônomo, e é por isso que
+queremos trabalhar com alguém que fique conosco por muito tempo.`,
+
+	"nl": `Wij zijn op zoek naar een ervaren ontwikkelaar die ons kleine team komt versterken en ons helpt
+om de volgende versie van ons platform te bouwen. De ideale kandidaat heeft een goede kennis van
+backend ontwikkeling, schrijft nette en goed geteste code, en vindt het prettig om samen te werken met
+een team dat over verschillende tijdzones is verdeeld. Je bent verantwoordelijk voor het ontwerpen van
+nieuwe functies, het oplossen van fouten en het verbeteren van de prestaties van het bestaande
+systeem. Voeg in je voorstel voorbeelden toe van vergelijkbare projecten waaraan je hebt gewerkt, en
+laat ons weten hoe lang het volgens jou zou duren. Het gaat om een project voor de lange termijn met
+de mogelijkheid van een vaste samenwerking voor de juiste persoon. Ons bedrijf is een paar jaar
+geleden opgericht en is sindsdien snel gegroeid. Wij hechten waarde aan duidelijke communicatie,
+eerlijke schattingen en mensen die verantwoordelijkheid nemen voor hun werk. Als je vragen hebt over
+de eisen, stel ze dan gerust voordat je je sollicitatie indient. Het budget is flexibel afhankelijk
+van de ervaring. We willen zo snel mogelijk beginnen en verwachten regelmatig een update tijdens de
+week. Gisteren was het mooi weer, dus zijn ze met hun kinderen in het park gaan wandelen en hebben ze
+gepraat over wat ze volgende zomer willen doen. Niets is belangrijker dan het vertrouwen tussen de
+opdrachtgever en de freelancer.`,
+
+	"pl": `Szukamy doświadczonego programisty, który dołączy do naszego małego zespołu i pomoże nam
+zbudować kolejną wersję naszej platformy. Idealny kandydat ma solidną wiedzę z zakresu programowania
+backendu, pisze czysty i dobrze przetestowany kod oraz dobrze czuje się w pracy z zespołem
+rozproszonym w różnych strefach czasowych. Będziesz odpowiedzialny za projektowanie nowych funkcji,
+naprawianie błędów i poprawę wydajności istniejącego systemu. Prosimy o dołączenie do oferty
+przykładów podobnych projektów, nad którymi pracowałeś, oraz informacji, ile czasu zajęłaby
+realizacja. Jest to projekt długoterminowy z możliwością stałej współpracy dla odpowiedniej osoby.
+Nasza firma została założona kilka lat temu i od tego czasu szybko się rozwija. Cenimy jasną
+komunikację, uczciwe wyceny i ludzi, którzy biorą odpowiedzialność za swoją pracę. Jeśli masz pytania
+dotyczące wymagań, śmiało zapytaj przed wysłaniem zgłoszenia. Budżet jest elastyczny w zależności od
+doświadczenia. Chcielibyśmy zacząć jak najszybciej i oczekujemy regularnych informacji w ciągu
+tygodnia. Wczoraj była ładna pogoda, więc poszli z dziećmi na spacer do parku i rozmawiali o tym, co
+będą robić następnego lata. Nie ma nic ważniejszego niż zaufanie między klientem a wykonawcą, dlatego
+chcemy pracować z kimś, kto zostanie z nami na dłużej.`,
+}
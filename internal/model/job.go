@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -39,6 +40,9 @@ type Job struct {
 	// Skill tags
 	Skills []string `json:"skills"`
 
+	// Detected ISO 639-1 language code of title and description, empty if unknown
+	Language string `json:"language,omitempty"`
+
 	// Time information
 	PostedAt  time.Time `json:"posted_at"`
 	FetchedAt time.Time `json:"fetched_at"`
@@ -68,9 +72,31 @@ func (j *Job) BudgetDisplay() string {
 	return "Hourly rate not specified"
 }
 
+// Budget returns the amount budgets are compared by: the fixed price
+// budget for fixed jobs and the hourly rate otherwise, preferring the upper
+// bound. ok is false when the job states neither.
+func (j *Job) Budget() (budget float64, ok bool) {
+	upper, lower := j.HourlyRateMax, j.HourlyRateMin
+	if j.JobType == JobTypeFixed {
+		upper, lower = j.BudgetMax, j.BudgetMin
+	}
+	switch {
+	case upper != nil:
+		return *upper, true
+	case lower != nil:
+		return *lower, true
+	}
+	return 0, false
+}
+
 // PostedAgo returns a human-readable time since posting
 func (j *Job) PostedAgo() string {
-	delta := time.Since(j.PostedAt)
+	return TimeAgo(j.PostedAt)
+}
+
+// TimeAgo returns a human-readable time since t, e.g. "3 hours ago"
+func TimeAgo(t time.Time) string {
+	delta := time.Since(t)
 	hours := delta.Hours()
 
 	if hours < 1 {
@@ -93,20 +119,80 @@ func (j *Job) PostedAgo() string {
 	return fmt.Sprintf("%d days ago", days)
 }
 
-// MatchedJob represents a job that matched the search criteria
-type MatchedJob struct {
-	Job             *Job     `json:"job"`
-	MatchedKeywords []string `json:"matched_keywords"`
+// SearchMatch represents a single search that matched a job
+type SearchMatch struct {
 	SearchName      string   `json:"search_name"`
+	MatchedKeywords []string `json:"matched_keywords"`
 	MatchScore      float64  `json:"match_score"`
 }
 
+// MatchedJob represents a job that matched the search criteria.
+// SearchName and MatchScore refer to the best matching search, and
+// MatchedKeywords is the union of keywords across all Matches.
+type MatchedJob struct {
+	Job             *Job          `json:"job"`
+	MatchedKeywords []string      `json:"matched_keywords"`
+	SearchName      string        `json:"search_name"`
+	MatchScore      float64       `json:"match_score"`
+	Matches         []SearchMatch `json:"matches"`
+}
+
 // NewMatchedJob creates a new matched job instance
-func NewMatchedJob(job *Job, keywords []string, searchName string) *MatchedJob {
+func NewMatchedJob(job *Job, keywords []string, searchName string, score float64) *MatchedJob {
 	return &MatchedJob{
 		Job:             job,
 		MatchedKeywords: keywords,
 		SearchName:      searchName,
-		MatchScore:      1.0,
+		MatchScore:      score,
+		Matches: []SearchMatch{
+			{SearchName: searchName, MatchedKeywords: keywords, MatchScore: score},
+		},
+	}
+}
+
+// AddMatch records a search that matched the job. The first or best scoring
+// search becomes the primary one and new keywords are merged into
+// MatchedKeywords.
+func (m *MatchedJob) AddMatch(searchName string, keywords []string, score float64) {
+	for _, existing := range m.Matches {
+		if existing.SearchName == searchName {
+			return
+		}
+	}
+
+	m.Matches = append(m.Matches, SearchMatch{
+		SearchName:      searchName,
+		MatchedKeywords: keywords,
+		MatchScore:      score,
+	})
+
+	if len(m.Matches) == 1 || score > m.MatchScore {
+		m.SearchName = searchName
+		m.MatchScore = score
+	}
+
+	union := make([]string, 0, len(m.MatchedKeywords)+len(keywords))
+	union = append(union, m.MatchedKeywords...)
+	for _, keyword := range keywords {
+		found := false
+		for _, k := range union {
+			if strings.EqualFold(k, keyword) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			union = append(union, keyword)
+		}
+	}
+	m.MatchedKeywords = union
+}
+
+// SearchNames returns the names of all searches that matched the job
+func (m *MatchedJob) SearchNames() []string {
+	names := make([]string, 0, len(m.Matches))
+	for _, match := range m.Matches {
+		names = append(names, match.SearchName)
 	}
+	return names
 }
@@ -145,7 +145,7 @@ func TestNewMatchedJob(t *testing.T) {
 	keywords := []string{"golang", "api"}
 	searchName := "Test Search"
 
-	matched := NewMatchedJob(job, keywords, searchName)
+	matched := NewMatchedJob(job, keywords, searchName, 0.5)
 
 	if matched.Job != job {
 		t.Error("Job reference mismatch")
@@ -159,9 +159,38 @@ func TestNewMatchedJob(t *testing.T) {
 		t.Errorf("SearchName = %v, want %v", matched.SearchName, searchName)
 	}
 
+	if matched.MatchScore != 0.5 || matched.Matches[0].MatchScore != 0.5 {
+		t.Errorf("MatchScore = %v, want the given 0.5", matched.MatchScore)
+	}
+}
+
+func TestMatchedJob_AddMatch(t *testing.T) {
+	matched := &MatchedJob{Job: &Job{ID: "test-123"}}
+
+	matched.AddMatch("Golang API", []string{"golang", "api"}, 0.5)
+	matched.AddMatch("Microservices", []string{"grpc", "API"}, 1.0)
+	matched.AddMatch("Golang API", []string{"golang"}, 1.0)
+
+	if len(matched.Matches) != 2 {
+		t.Fatalf("Matches count = %d, want 2", len(matched.Matches))
+	}
+
+	if matched.SearchName != "Microservices" {
+		t.Errorf("SearchName = %v, want Microservices", matched.SearchName)
+	}
+
 	if matched.MatchScore != 1.0 {
 		t.Errorf("MatchScore = %v, want 1.0", matched.MatchScore)
 	}
+
+	if len(matched.MatchedKeywords) != 3 {
+		t.Errorf("MatchedKeywords = %v, want 3 unique keywords", matched.MatchedKeywords)
+	}
+
+	names := matched.SearchNames()
+	if len(names) != 2 || names[0] != "Golang API" || names[1] != "Microservices" {
+		t.Errorf("SearchNames() = %v, want [Golang API Microservices]", names)
+	}
 }
 
 func floatPtr(f float64) *float64 {
@@ -6,13 +6,14 @@ import "time"
 type NotifyStatus string
 
 const (
-	NotifyStatusPending NotifyStatus = "pending"
+	NotifyStatusPending NotifyStatus = "pending" // Queued, or waiting for a retry
 	NotifyStatusSent    NotifyStatus = "sent"
-	NotifyStatusFailed  NotifyStatus = "failed"
+	NotifyStatusFailed  NotifyStatus = "failed" // Gave up after the last retry
 	NotifyStatusSkipped NotifyStatus = "skipped"
 )
 
-// NotifyRecord represents a notification record
+// NotifyRecord represents the delivery of one job to one channel. Pending
+// records form the outbox that is retried until sent or expired.
 type NotifyRecord struct {
 	ID              int64        `json:"id" db:"id"`
 	JobID           string       `json:"job_id" db:"job_id"`
@@ -23,8 +24,39 @@ type NotifyRecord struct {
 	NotifyChannel   string       `json:"notify_channel" db:"notify_channel"`
 	Status          NotifyStatus `json:"status" db:"status"`
 	ErrorMessage    string       `json:"error_message,omitempty" db:"error_message"`
+	Attempts        int          `json:"attempts" db:"attempts"`
 	CreatedAt       time.Time    `json:"created_at" db:"created_at"`
 	SentAt          *time.Time   `json:"sent_at,omitempty" db:"sent_at"`
+	NextAttemptAt   *time.Time   `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+
+	// Matched job to deliver, stored with pending records only
+	Job *MatchedJob `json:"-" db:"payload"`
+}
+
+// Stuck reports whether the delivery failed, or has failed at least once
+// and is waiting for a retry
+func (r *NotifyRecord) Stuck() bool {
+	return r.Status == NotifyStatusFailed || (r.Status == NotifyStatusPending && r.Attempts > 0)
+}
+
+// NotifyQuery represents filters for querying notification records
+type NotifyQuery struct {
+	Since      time.Time    // Only records created at or after this time
+	Until      time.Time    // Only records created before this time
+	SearchName string       // Only notifications for jobs matched by this search
+	Channel    string       // Only this notification channel
+	Status     NotifyStatus // Only records with this status
+	Stuck      bool         // Only failed records and pending retries
+	Limit      int          // Max records to return, 0 for no limit
+}
+
+// SearchMatchRecord is a stored match of a notified job against one search
+type SearchMatchRecord struct {
+	JobID           string    `json:"job_id"`
+	SearchName      string    `json:"search_name"`
+	MatchedKeywords []string  `json:"matched_keywords"`
+	MatchScore      float64   `json:"match_score"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 // JobSeen represents a record of a job that has been seen (for deduplication)
@@ -36,3 +68,47 @@ type JobSeen struct {
 	Notified    bool      `json:"notified" db:"notified"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
+
+// JobRecord represents a stored job together with its match result
+type JobRecord struct {
+	Job         *Job          `json:"job"`
+	Searches    []string      `json:"searches"` // Searches or feeds that returned the job
+	Matches     []SearchMatch `json:"matches"`  // Searches whose keywords matched
+	MatchScore  float64       `json:"match_score"`
+	FirstSeenAt time.Time     `json:"first_seen_at"`
+	LastSeenAt  time.Time     `json:"last_seen_at"`
+}
+
+// Matched reports whether any search matched the job
+func (r *JobRecord) Matched() bool {
+	return len(r.Matches) > 0
+}
+
+// JobQuery represents filters for querying stored jobs
+type JobQuery struct {
+	Since       time.Time // Only jobs last seen at or after this time
+	Until       time.Time // Only jobs last seen before this time
+	SearchName  string    // Only jobs returned by this search
+	Skill       string    // Only jobs tagged with this skill (case-insensitive)
+	MatchedOnly bool      // Only jobs that matched at least one search
+	Limit       int       // Max records to return, 0 for no limit
+}
+
+// JobSearch represents a full-text search over stored jobs
+type JobSearch struct {
+	Query          string    // Search terms; quoted phrases, OR and trailing * are supported
+	Since          time.Time // Only jobs posted at or after this time
+	Until          time.Time // Only jobs posted before this time
+	BudgetMin      float64   // Minimum budget or hourly rate, 0 for no minimum
+	BudgetMax      float64   // Maximum budget or hourly rate, 0 for no maximum
+	Limit          int       // Max results to return, 0 for no limit
+	HighlightStart string    // Inserted before each matched term in snippets
+	HighlightEnd   string    // Inserted after each matched term in snippets
+}
+
+// JobSearchResult is a stored job returned by a full-text search
+type JobSearchResult struct {
+	*JobRecord
+	Rank    float64 `json:"rank"`    // Relevance, higher is better
+	Snippet string  `json:"snippet"` // Matching excerpt with highlighted terms
+}
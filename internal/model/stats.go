@@ -4,6 +4,7 @@ import "time"
 
 // RunStats represents statistics for a single run
 type RunStats struct {
+	ID              int64      `json:"id,omitempty"` // Set when loaded from storage
 	StartedAt       time.Time  `json:"started_at"`
 	FinishedAt      *time.Time `json:"finished_at,omitempty"`
 	DurationSeconds float64    `json:"duration_seconds"`
@@ -12,6 +13,9 @@ type RunStats struct {
 	JobsNotified    int        `json:"jobs_notified"`
 	JobsSkipped     int        `json:"jobs_skipped"`
 	ErrorMessage    string     `json:"error_message,omitempty"`
+
+	// Sources holds one result per fetch. Loaded only for a single run.
+	Sources []*SourceResult `json:"sources,omitempty"`
 }
 
 // NewRunStats creates a new RunStats instance with start time set
@@ -28,12 +32,72 @@ func (s *RunStats) Finish() {
 	s.DurationSeconds = now.Sub(s.StartedAt).Seconds()
 }
 
+// FailedSources returns the sources that could not be fetched
+func (s *RunStats) FailedSources() []*SourceResult {
+	var failed []*SourceResult
+	for _, r := range s.Sources {
+		if r.Failed() {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// Source types of a SourceResult
+const (
+	SourceAPI       = "api"        // Upwork GraphQL API search for one keyword
+	SourceRSS       = "rss"        // Configured RSS feed URL
+	SourceRSSSearch = "rss_search" // Deprecated public RSS keyword search
+)
+
+// SourceResult records the outcome of fetching one source during a run
+type SourceResult struct {
+	Source          string    `json:"source"`
+	SearchName      string    `json:"search_name"` // Search or feed name
+	Keyword         string    `json:"keyword,omitempty"`
+	StartedAt       time.Time `json:"started_at"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Fetched         int       `json:"fetched"`
+	HTTPStatus      int       `json:"http_status,omitempty"` // 0 if no response was received
+	ErrorMessage    string    `json:"error_message,omitempty"`
+}
+
+// Failed reports whether the fetch returned an error
+func (r *SourceResult) Failed() bool {
+	return r.ErrorMessage != ""
+}
+
+// Label names the source for display, e.g. "Golang API / graphql"
+func (r *SourceResult) Label() string {
+	if r.Keyword == "" {
+		return r.SearchName
+	}
+	return r.SearchName + " / " + r.Keyword
+}
+
+// RunLogQuery represents filters for querying run logs
+type RunLogQuery struct {
+	Since  time.Time // Only runs started at or after this time
+	Until  time.Time // Only runs started before this time
+	Limit  int       // Max records to return, 0 for no limit
+	Failed bool      // Only runs that recorded an error
+}
+
 // OverallStats represents aggregate statistics
 type OverallStats struct {
 	TotalRuns         int        `json:"total_runs"`
 	TotalJobsFetched  int        `json:"total_jobs_fetched"`
 	TotalJobsMatched  int        `json:"total_jobs_matched"`
 	TotalJobsNotified int        `json:"total_jobs_notified"`
+	TrackingSince     *time.Time `json:"tracking_since,omitempty"` // First recorded run
 	LastRunAt         *time.Time `json:"last_run_at,omitempty"`
 	LastMatchAt       *time.Time `json:"last_match_at,omitempty"`
 }
+
+// SearchStats represents match statistics for a single search
+type SearchStats struct {
+	SearchName   string     `json:"search_name"`
+	TotalMatches int        `json:"total_matches"`
+	AvgScore     float64    `json:"avg_score"`
+	LastMatchAt  *time.Time `json:"last_match_at,omitempty"`
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TrackStatus is where a job stands in your own application pipeline
+type TrackStatus string
+
+const (
+	TrackStatusNew          TrackStatus = "new"
+	TrackStatusSaved        TrackStatus = "saved"
+	TrackStatusApplied      TrackStatus = "applied"
+	TrackStatusInterviewing TrackStatus = "interviewing"
+	TrackStatusHired        TrackStatus = "hired"
+	TrackStatusLost         TrackStatus = "lost"
+	TrackStatusIgnored      TrackStatus = "ignored"
+)
+
+// TrackStatuses lists all statuses in pipeline order
+var TrackStatuses = []TrackStatus{
+	TrackStatusNew, TrackStatusSaved, TrackStatusApplied, TrackStatusInterviewing,
+	TrackStatusHired, TrackStatusLost, TrackStatusIgnored,
+}
+
+// ParseTrackStatus parses a status name (case-insensitive)
+func ParseTrackStatus(s string) (TrackStatus, error) {
+	status := TrackStatus(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range TrackStatuses {
+		if status == known {
+			return status, nil
+		}
+	}
+
+	names := make([]string, len(TrackStatuses))
+	for i, known := range TrackStatuses {
+		names[i] = string(known)
+	}
+	return "", fmt.Errorf("unknown status %q (use %s)", s, strings.Join(names, ", "))
+}
+
+// Applied reports whether a proposal has been sent for a job in this status
+func (s TrackStatus) Applied() bool {
+	switch s {
+	case TrackStatusApplied, TrackStatusInterviewing, TrackStatusHired, TrackStatusLost:
+		return true
+	}
+	return false
+}
+
+// Closed reports whether the status ends the pipeline
+func (s TrackStatus) Closed() bool {
+	switch s {
+	case TrackStatusHired, TrackStatusLost, TrackStatusIgnored:
+		return true
+	}
+	return false
+}
+
+// TrackedJob is a job you are acting on. Title, URL, searches and keywords
+// are copied from the job when tracking starts, so the record outlives the
+// job under storage retention.
+type TrackedJob struct {
+	JobID     string         `json:"job_id"`
+	JobTitle  string         `json:"job_title"`
+	JobURL    string         `json:"job_url"`
+	Searches  []string       `json:"searches"` // Searches that matched the job
+	Keywords  []string       `json:"keywords"` // Keywords that matched the job
+	Status    TrackStatus    `json:"status"`
+	Bid       *float64       `json:"bid,omitempty"` // Proposed rate or fixed price
+	Connects  int            `json:"connects"`      // Connects spent on the proposal
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	AppliedAt *time.Time     `json:"applied_at,omitempty"` // First moved to applied or later
+	ClosedAt  *time.Time     `json:"closed_at,omitempty"`  // Moved to hired, lost or ignored
+	Notes     []JobNote      `json:"notes,omitempty"`
+	History   []StatusChange `json:"history,omitempty"`
+}
+
+// JobNote is a free-text note on a tracked job
+type JobNote struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StatusChange records a tracked job moving to a status
+type StatusChange struct {
+	Status    TrackStatus `json:"status"`
+	ChangedAt time.Time   `json:"changed_at"`
+}
+
+// TrackUpdate changes a tracked job, starting to track it if needed.
+// Unset fields are left as they are.
+type TrackUpdate struct {
+	JobRef   string      // Job ID or URL
+	Status   TrackStatus // New status, empty to keep (new for untracked jobs)
+	Bid      *float64    // New bid
+	Connects *int        // Total connects spent
+	Note     string      // Note to add
+}
+
+// TrackQuery represents filters for listing tracked jobs
+type TrackQuery struct {
+	Statuses   []TrackStatus // Only jobs in one of these statuses
+	SearchName string        // Only jobs matched by this search
+	Limit      int           // Max records to return, 0 for no limit
+}
+
+// WinRate summarizes pipeline outcomes for a search, keyword or overall
+type WinRate struct {
+	Name         string  `json:"name"`
+	Tracked      int     `json:"tracked"`
+	Applied      int     `json:"applied"` // Proposals sent, including later stages
+	Interviewing int     `json:"interviewing"`
+	Hired        int     `json:"hired"`
+	Lost         int     `json:"lost"`
+	Connects     int     `json:"connects"`
+	AvgBid       float64 `json:"avg_bid"` // Average bid of applied jobs with a bid
+}
+
+// Rate returns hires per proposal sent, 0 when nothing was applied for
+func (w *WinRate) Rate() float64 {
+	if w.Applied == 0 {
+		return 0
+	}
+	return float64(w.Hired) / float64(w.Applied)
+}
+
+// ConnectsPerHire returns connects spent per hire, 0 without hires
+func (w *WinRate) ConnectsPerHire() float64 {
+	if w.Hired == 0 {
+		return 0
+	}
+	return float64(w.Connects) / float64(w.Hired)
+}
+
+// WinRateReport breaks pipeline outcomes down by search and keyword
+type WinRateReport struct {
+	Overall   WinRate    `json:"overall"`
+	BySearch  []*WinRate `json:"by_search"`
+	ByKeyword []*WinRate `json:"by_keyword"`
+}
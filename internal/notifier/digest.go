@@ -0,0 +1,245 @@
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	texttemplate "text/template"
+	"unicode/utf8"
+
+	"jobradar/internal/model"
+	"jobradar/internal/templates"
+)
+
+// DigestNotifier is a notifier that can send several jobs as one summary
+type DigestNotifier interface {
+	Notifier
+
+	// SendDigest sends jobs, best first, in as few messages as the channel's
+	// size limits allow. It returns how many of the leading jobs were
+	// delivered, which is less than len(jobs) only together with an error.
+	SendDigest(jobs []*model.MatchedJob) (int, error)
+}
+
+// Digest size limits per message
+const (
+	telegramMaxMessage  = 4096 // Characters
+	telegramDigestLimit = telegramMaxMessage - 200
+	emailDigestMaxJobs  = 50
+	slackDigestMaxJobs  = 45 // Slack allows 50 blocks, leaving room for the header
+	discordMaxDesc      = 4096
+)
+
+// packDigest groups consecutive entries into chunks of at most maxItems
+// entries whose combined length, with sep between entries, stays within
+// maxLen characters. An entry too long for a chunk of its own is cut.
+// Zero disables either limit.
+func packDigest(entries []string, sep string, maxLen, maxItems int) [][]string {
+	var chunks [][]string
+	var current []string
+	size := 0
+	for _, entry := range entries {
+		if maxLen > 0 {
+			entry = templates.Truncate(maxLen, entry)
+		}
+		n := utf8.RuneCountInString(entry)
+		if len(current) > 0 {
+			n += utf8.RuneCountInString(sep)
+		}
+		full := (maxItems > 0 && len(current) >= maxItems) || (maxLen > 0 && size+n > maxLen)
+		if full && len(current) > 0 {
+			chunks = append(chunks, current)
+			current, size = nil, 0
+			n = utf8.RuneCountInString(entry)
+		}
+		current = append(current, entry)
+		size += n
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// digestTitle returns the heading of one message of a digest
+func digestTitle(total, part, parts int) string {
+	title := fmt.Sprintf("JobRadar digest: %d new match", total)
+	if total != 1 {
+		title += "es"
+	}
+	if parts > 1 {
+		title += fmt.Sprintf(" (%d/%d)", part, parts)
+	}
+	return title
+}
+
+// digestLine returns the budget, score and posted time of a job on one line
+func digestLine(matched *model.MatchedJob) string {
+	return fmt.Sprintf("💰 %s · ⭐ %.2f · ⏰ %s", matched.Job.BudgetDisplay(), matched.MatchScore, matched.Job.PostedAgo())
+}
+
+// chunkSizes returns how many entries each chunk holds
+func chunkSizes(chunks [][]string) []int {
+	sizes := make([]int, len(chunks))
+	for i, chunk := range chunks {
+		sizes[i] = len(chunk)
+	}
+	return sizes
+}
+
+// sendDigest sends the messages of a digest in turn, given how many jobs
+// each holds, and counts the jobs delivered until one fails
+func sendDigest(sizes []int, send func(i int) error) (int, error) {
+	delivered := 0
+	for i, size := range sizes {
+		if err := send(i); err != nil {
+			return delivered, err
+		}
+		delivered += size
+	}
+	return delivered, nil
+}
+
+// FormatTelegramDigest formats jobs as MarkdownV2 messages within
+// Telegram's message length limit, returning how many jobs each holds
+func FormatTelegramDigest(jobs []*model.MatchedJob) (messages []string, sizes []int) {
+	entries := make([]string, len(jobs))
+	for i, m := range jobs {
+		entries[i] = fmt.Sprintf("*%d\\.* [%s](%s)\n%s\n🔎 %s",
+			i+1, templates.EscapeMD(m.Job.Title), templates.EscapeMDURL(m.Job.URL),
+			templates.EscapeMD(digestLine(m)), templates.EscapeMD(strings.Join(m.SearchNames(), ", ")))
+	}
+
+	chunks := packDigest(entries, "\n\n", telegramDigestLimit, 0)
+	for i, chunk := range chunks {
+		title := templates.EscapeMD(digestTitle(len(jobs), i+1, len(chunks)))
+		messages = append(messages, "📬 *"+title+"*\n\n"+strings.Join(chunk, "\n\n"))
+	}
+	return messages, chunkSizes(chunks)
+}
+
+// emailDigestTemplate is the HTML body of a digest email
+var emailDigestTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"></head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #2c5282;">📬 {{ .Title }}</h2>
+        {{- range .Jobs }}
+        <div style="border-left: 4px solid #4299e1; padding: 10px 15px; margin: 15px 0;">
+            <strong><a href="{{ .URL }}">{{ .Title }}</a></strong><br/>
+            {{ .Line }}<br/>
+            🔎 {{ .Searches }}
+            {{- if .Description }}
+            <p style="color: #4a5568;">{{ .Description }}</p>
+            {{- end }}
+        </div>
+        {{- end }}
+        <p style="font-size: 12px; color: #718096;">This digest was sent by JobRadar.</p>
+    </div>
+</body>
+</html>`))
+
+// emailDigestTextTemplate is the plain text body of a digest email
+var emailDigestTextTemplate = texttemplate.Must(texttemplate.New("digest").Parse(`{{ .Title }}
+{{ range .Jobs }}
+{{ .Title }}
+{{ .Line }}
+Searches: {{ .Searches }}
+{{ .URL }}
+{{ end }}
+--
+This digest was sent by JobRadar.
+`))
+
+// FormatEmailDigest formats jobs as digest emails of at most
+// emailDigestMaxJobs jobs each, returning the emails and how many jobs
+// each holds
+func FormatEmailDigest(jobs []*model.MatchedJob) (emails []EmailContent, sizes []int, err error) {
+	type entry struct {
+		Title, URL, Line, Searches, Description string
+	}
+
+	parts := (len(jobs) + emailDigestMaxJobs - 1) / emailDigestMaxJobs
+	for part := 0; part < parts; part++ {
+		title := digestTitle(len(jobs), part+1, parts)
+		data := struct {
+			Title string
+			Jobs  []entry
+		}{Title: title}
+		start := part * emailDigestMaxJobs
+		end := min(start+emailDigestMaxJobs, len(jobs))
+		for _, m := range jobs[start:end] {
+			data.Jobs = append(data.Jobs, entry{
+				Title:       m.Job.Title,
+				URL:         m.Job.URL,
+				Line:        digestLine(m),
+				Searches:    strings.Join(m.SearchNames(), ", "),
+				Description: templates.Truncate(200, m.Job.Description),
+			})
+		}
+
+		var html, text bytes.Buffer
+		if err := emailDigestTemplate.Execute(&html, data); err != nil {
+			return nil, nil, fmt.Errorf("failed to render digest: %w", err)
+		}
+		if err := emailDigestTextTemplate.Execute(&text, data); err != nil {
+			return nil, nil, fmt.Errorf("failed to render digest: %w", err)
+		}
+		emails = append(emails, EmailContent{Subject: title, Text: text.String(), HTML: html.String()})
+		sizes = append(sizes, end-start)
+	}
+	return emails, sizes, nil
+}
+
+// FormatSlackDigest formats jobs as Block Kit messages of at most
+// slackDigestMaxJobs sections each, returning how many jobs each holds
+func FormatSlackDigest(jobs []*model.MatchedJob) (messages []*SlackMessage, sizes []int) {
+	entries := make([]string, len(jobs))
+	for i, m := range jobs {
+		entries[i] = fmt.Sprintf("*%d. <%s|%s>*\n%s\n🔎 %s",
+			i+1, m.Job.URL, escapeSlack(m.Job.Title),
+			escapeSlack(digestLine(m)), escapeSlack(strings.Join(m.SearchNames(), ", ")))
+	}
+
+	chunks := packDigest(entries, "", 0, slackDigestMaxJobs)
+	for i, chunk := range chunks {
+		title := digestTitle(len(jobs), i+1, len(chunks))
+		blocks := []SlackBlock{slackSection("📬 *" + escapeSlack(title) + "*")}
+		for _, entry := range chunk {
+			blocks = append(blocks, slackSection(entry))
+		}
+		messages = append(messages, &SlackMessage{Text: escapeSlack(title), Blocks: blocks})
+	}
+	return messages, chunkSizes(chunks)
+}
+
+// FormatDiscordDigest formats jobs as embeds whose description stays
+// within Discord's limit, returning how many jobs each holds
+func FormatDiscordDigest(jobs []*model.MatchedJob) (embeds []*DiscordEmbed, sizes []int) {
+	entries := make([]string, len(jobs))
+	for i, m := range jobs {
+		entries[i] = fmt.Sprintf("**%d.** [%s](%s)\n%s · 🔎 %s",
+			i+1, escapeDiscord(m.Job.Title), m.Job.URL,
+			digestLine(m), escapeDiscord(strings.Join(m.SearchNames(), ", ")))
+	}
+
+	chunks := packDigest(entries, "\n\n", discordMaxDesc, 0)
+	for i, chunk := range chunks {
+		embeds = append(embeds, &DiscordEmbed{
+			Title:       "📬 " + digestTitle(len(jobs), i+1, len(chunks)),
+			Description: strings.Join(chunk, "\n\n"),
+			Color:       discordColorFixed,
+		})
+	}
+	return embeds, chunkSizes(chunks)
+}
+
+// escapeDiscord escapes Discord markdown in user text
+func escapeDiscord(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`",
+		"[", `\[`, "]", `\]`, "|", `\|`, ">", `\>`,
+	).Replace(s)
+}
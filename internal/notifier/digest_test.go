@@ -0,0 +1,100 @@
+package notifier
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+)
+
+func testDigestJobs(n int) []*model.MatchedJob {
+	jobs := make([]*model.MatchedJob, n)
+	for i := range jobs {
+		matched := testMatchedJob()
+		matched.Job.ID = fmt.Sprintf("~0%d", i)
+		matched.Job.Title = fmt.Sprintf("Job %d: %s", i+1, strings.Repeat("x", 150))
+		jobs[i] = matched
+	}
+	return jobs
+}
+
+func TestPackDigest(t *testing.T) {
+	chunks := packDigest([]string{"aaaa", "bbbb", "cccc", strings.Repeat("d", 20)}, "\n", 10, 0)
+	got := fmt.Sprint(chunks)
+	if want := "[[aaaa bbbb] [cccc] [ddddddd...]]"; got != want {
+		t.Errorf("packDigest() = %s, want %s", got, want)
+	}
+
+	chunks = packDigest([]string{"a", "b", "c"}, "", 0, 2)
+	if len(chunks) != 2 || len(chunks[0]) != 2 {
+		t.Errorf("packDigest() = %v, want chunks of 2", chunks)
+	}
+}
+
+func TestFormatTelegramDigest_Split(t *testing.T) {
+	jobs := testDigestJobs(40)
+	messages, sizes := FormatTelegramDigest(jobs)
+	if len(messages) < 2 {
+		t.Fatalf("got %d messages, want the digest split", len(messages))
+	}
+
+	total := 0
+	for i, msg := range messages {
+		if n := utf8.RuneCountInString(msg); n > telegramMaxMessage {
+			t.Errorf("message %d is %d characters", i+1, n)
+		}
+		if !strings.Contains(msg, fmt.Sprintf(`40 new matches \(%d/%d\)`, i+1, len(messages))) {
+			t.Errorf("message %d has no part header:\n%s", i+1, msg[:80])
+		}
+		total += sizes[i]
+	}
+	if total != len(jobs) {
+		t.Errorf("messages hold %d jobs, want %d", total, len(jobs))
+	}
+	if !strings.Contains(messages[len(messages)-1], `*40\.*`) {
+		t.Error("numbering does not continue across messages")
+	}
+}
+
+func TestSlackNotifier_SendDigestPartial(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		if posts > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	n := NewSlack(config.SlackConfig{WebhookURL: server.URL})
+	delivered, err := n.SendDigest(testDigestJobs(slackDigestMaxJobs + 5))
+	if err == nil {
+		t.Fatal("SendDigest() should fail when the second message fails")
+	}
+	if delivered != slackDigestMaxJobs {
+		t.Errorf("delivered = %d, want the %d jobs of the first message", delivered, slackDigestMaxJobs)
+	}
+}
+
+func TestFormatEmailDigest(t *testing.T) {
+	emails, sizes, err := FormatEmailDigest(testDigestJobs(3))
+	if err != nil {
+		t.Fatalf("FormatEmailDigest() error = %v", err)
+	}
+	if len(emails) != 1 || emails[0].Subject != "JobRadar digest: 3 new matches" || sizes[0] != 3 {
+		t.Fatalf("emails = %v, sizes = %v", emails, sizes)
+	}
+	if strings.Count(emails[0].HTML, `href="https://www.upwork.com/jobs/~01a"`) != 3 || !strings.Contains(emails[0].HTML, "Job 3: ") {
+		t.Errorf("html body does not list the jobs:\n%s", emails[0].HTML)
+	}
+	if strings.Count(emails[0].Text, "https://www.upwork.com/jobs/~01a\n") != 3 || !strings.Contains(emails[0].Text, "Job 3: ") {
+		t.Errorf("text body does not list the jobs:\n%s", emails[0].Text)
+	}
+}
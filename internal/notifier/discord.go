@@ -0,0 +1,267 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"jobradar/internal/config"
+	"jobradar/internal/langdetect"
+	"jobradar/internal/model"
+	"jobradar/internal/templates"
+)
+
+// Rate limit handling: a 429 is retried after the wait Discord asks for,
+// unless that wait is too long to block a check cycle on
+const (
+	discordMaxRetries = 3
+	discordMaxWait    = 30 * time.Second
+)
+
+// Embed colours by job type
+const (
+	discordColorFixed   = 0x2ECC71 // Green
+	discordColorHourly  = 0x3498DB // Blue
+	discordColorUnknown = 0x95A5A6 // Gray
+)
+
+// Discord embed limits, in characters
+const (
+	discordMaxTitle      = 256
+	discordMaxFields     = 25
+	discordMaxFieldName  = 256
+	discordMaxFieldValue = 1024
+	discordMaxFooter     = 2048
+	discordMaxEmbedTotal = 6000
+)
+
+// DiscordNotifier sends notifications to a Discord channel via a webhook
+type DiscordNotifier struct {
+	config config.DiscordConfig
+	client *http.Client
+}
+
+// NewDiscord creates a new Discord notifier
+func NewDiscord(cfg config.DiscordConfig) *DiscordNotifier {
+	return &DiscordNotifier{
+		config: cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the notifier name
+func (d *DiscordNotifier) Name() string {
+	return d.config.ChannelName()
+}
+
+// Send sends a notification for a matched job
+func (d *DiscordNotifier) Send(matched *model.MatchedJob) error {
+	return d.post(&DiscordMessage{Embeds: []*DiscordEmbed{FormatDiscordEmbed(matched)}})
+}
+
+// SendDigest sends jobs as one or more summary embeds
+func (d *DiscordNotifier) SendDigest(jobs []*model.MatchedJob) (int, error) {
+	embeds, sizes := FormatDiscordDigest(jobs)
+	return sendDigest(sizes, func(i int) error {
+		return d.post(&DiscordMessage{Embeds: []*DiscordEmbed{embeds[i]}})
+	})
+}
+
+// SendTest sends a test notification
+func (d *DiscordNotifier) SendTest() error {
+	return d.post(&DiscordMessage{Embeds: []*DiscordEmbed{{
+		Title:       "🔔 JobRadar Test Notification",
+		Description: "If you see this message, your Discord webhook is configured correctly.",
+		Color:       discordColorFixed,
+	}}})
+}
+
+// post sends a message to the webhook, waiting and retrying when rate limited
+func (d *DiscordNotifier) post(msg *DiscordMessage) error {
+	if d.config.Username != "" {
+		msg.Username = d.config.Username
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := d.client.Post(d.config.WebhookURL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to send request: %w", err)
+		}
+		reply, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent:
+			return nil
+		case resp.StatusCode == http.StatusTooManyRequests:
+			wait := discordRetryAfter(resp.Header, reply)
+			if attempt > discordMaxRetries || wait > discordMaxWait {
+				return fmt.Errorf("discord webhook rate limited, retry after %s", wait)
+			}
+			time.Sleep(wait)
+		default:
+			var errResp struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(reply, &errResp); err == nil && errResp.Message != "" {
+				return fmt.Errorf("discord webhook error (status %d): %s", resp.StatusCode, errResp.Message)
+			}
+			return fmt.Errorf("discord webhook returned status %d", resp.StatusCode)
+		}
+	}
+}
+
+// discordRetryAfter reads how long to wait from a 429 response, preferring
+// the body's fractional retry_after over the Retry-After header
+func discordRetryAfter(header http.Header, body []byte) time.Duration {
+	var limited struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	if err := json.Unmarshal(body, &limited); err == nil && limited.RetryAfter > 0 {
+		return time.Duration(limited.RetryAfter * float64(time.Second))
+	}
+	if seconds, err := strconv.ParseFloat(header.Get("Retry-After"), 64); err == nil && seconds > 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	return time.Second
+}
+
+// DiscordMessage is a webhook payload
+type DiscordMessage struct {
+	Username string          `json:"username,omitempty"`
+	Content  string          `json:"content,omitempty"`
+	Embeds   []*DiscordEmbed `json:"embeds,omitempty"`
+}
+
+// DiscordEmbed is a rich embed
+type DiscordEmbed struct {
+	Title       string               `json:"title,omitempty"`
+	URL         string               `json:"url,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Color       int                  `json:"color"`
+	Fields      []*DiscordEmbedField `json:"fields,omitempty"`
+	Footer      *DiscordEmbedFooter  `json:"footer,omitempty"`
+	Timestamp   string               `json:"timestamp,omitempty"`
+}
+
+// DiscordEmbedField is a name/value pair shown in an embed
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// DiscordEmbedFooter is the small text under an embed
+type DiscordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// FormatDiscordEmbed formats a job notification as an embed, colour-coded
+// by job type and cut to fit Discord's length limits
+func FormatDiscordEmbed(matched *model.MatchedJob) *DiscordEmbed {
+	job := matched.Job
+
+	color := discordColorUnknown
+	switch job.JobType {
+	case model.JobTypeFixed:
+		color = discordColorFixed
+	case model.JobTypeHourly:
+		color = discordColorHourly
+	}
+
+	field := func(name, value string, inline bool) *DiscordEmbedField {
+		if value == "" {
+			value = "N/A"
+		}
+		return &DiscordEmbedField{
+			Name:   templates.Truncate(discordMaxFieldName, name),
+			Value:  templates.Truncate(discordMaxFieldValue, value),
+			Inline: inline,
+		}
+	}
+
+	proposals := ""
+	if job.Proposals != nil {
+		proposals = fmt.Sprintf("%d", *job.Proposals)
+	}
+
+	fields := []*DiscordEmbedField{
+		field("💰 Budget", job.BudgetDisplay(), true),
+		field("👥 Proposals", proposals, true),
+		field("📍 Client", job.ClientCountry, true),
+		field("⏰ Posted", job.PostedAgo(), true),
+		field("⭐ Score", fmt.Sprintf("%.2f", matched.MatchScore), true),
+	}
+	if job.Language != "" {
+		fields = append(fields, field("🌐 Language", langdetect.Name(job.Language), true))
+	}
+	if len(job.Skills) > 0 {
+		fields = append(fields, field("🏷️ Skills", strings.Join(job.Skills, ", "), false))
+	}
+	fields = append(fields, field("✅ Matched", strings.Join(matched.MatchedKeywords, ", "), false))
+	if len(matched.Matches) > 1 {
+		for _, m := range matched.Matches {
+			fields = append(fields, field("🔎 "+m.SearchName, strings.Join(m.MatchedKeywords, ", "), false))
+		}
+	}
+	if len(fields) > discordMaxFields {
+		fields = fields[:discordMaxFields]
+	}
+
+	embed := &DiscordEmbed{
+		Title:       templates.Truncate(discordMaxTitle, job.Title),
+		URL:         job.URL,
+		Description: templates.Truncate(500, job.Description),
+		Color:       color,
+		Fields:      fields,
+		Footer:      &DiscordEmbedFooter{Text: templates.Truncate(discordMaxFooter, "JobRadar · "+matched.SearchName)},
+	}
+	if !job.PostedAt.IsZero() {
+		embed.Timestamp = job.PostedAt.UTC().Format(time.RFC3339)
+	}
+
+	fitDiscordEmbed(embed)
+	return embed
+}
+
+// fitDiscordEmbed keeps an embed within the total character limit by
+// shortening the description, then dropping trailing fields
+func fitDiscordEmbed(embed *DiscordEmbed) {
+	size := func() int {
+		n := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+		if embed.Footer != nil {
+			n += utf8.RuneCountInString(embed.Footer.Text)
+		}
+		for _, f := range embed.Fields {
+			n += utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+		}
+		return n
+	}
+
+	over := size() - discordMaxEmbedTotal
+	if over <= 0 {
+		return
+	}
+
+	if desc := utf8.RuneCountInString(embed.Description); desc > 0 {
+		keep := desc - over
+		if keep < 0 {
+			keep = 0
+		}
+		embed.Description = templates.Truncate(keep, embed.Description)
+	}
+	for size() > discordMaxEmbedTotal && len(embed.Fields) > 0 {
+		embed.Fields = embed.Fields[:len(embed.Fields)-1]
+	}
+}
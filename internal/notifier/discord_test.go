@@ -0,0 +1,129 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+)
+
+func TestDiscordNotifier_Send(t *testing.T) {
+	var got DiscordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("payload is not JSON: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := NewDiscord(config.DiscordConfig{WebhookURL: server.URL, Username: "JobRadar"})
+	matched := testMatchedJob()
+	matched.Job.ClientCountry = "Germany"
+	if err := n.Send(matched); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if got.Username != "JobRadar" || len(got.Embeds) != 1 {
+		t.Fatalf("message = %+v, want one embed sent as JobRadar", got)
+	}
+	embed := got.Embeds[0]
+	if embed.Color != discordColorFixed || embed.URL != matched.Job.URL {
+		t.Errorf("embed color = %#x, url = %q", embed.Color, embed.URL)
+	}
+
+	values := make(map[string]string)
+	for _, f := range embed.Fields {
+		values[f.Name] = f.Value
+	}
+	for name, want := range map[string]string{
+		"💰 Budget":    "$500 (Fixed)",
+		"👥 Proposals": "7",
+		"📍 Client":    "Germany",
+		"🏷️ Skills":   "Go, PostgreSQL",
+		"✅ Matched":   "golang, api",
+	} {
+		if values[name] != want {
+			t.Errorf("field %s = %q, want %q", name, values[name], want)
+		}
+	}
+}
+
+func TestDiscordNotifier_RateLimited(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.05, "global": false}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := NewDiscord(config.DiscordConfig{WebhookURL: server.URL})
+	if err := n.Send(testMatchedJob()); err != nil {
+		t.Fatalf("Send() error = %v, want the retry to succeed", err)
+	}
+	if calls != 2 {
+		t.Errorf("webhook called %d times, want 2", calls)
+	}
+}
+
+func TestDiscordNotifier_RateLimitedTooLong(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"retry_after": 120}`))
+	}))
+	defer server.Close()
+
+	n := NewDiscord(config.DiscordConfig{WebhookURL: server.URL})
+	err := n.Send(testMatchedJob())
+	if err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Errorf("Send() error = %v, want a rate limit error without waiting", err)
+	}
+}
+
+func TestFormatDiscordEmbed_Limits(t *testing.T) {
+	matched := testMatchedJob()
+	matched.Job.JobType = model.JobTypeHourly
+	matched.Job.Title = strings.Repeat("T", 300)
+	matched.Job.Description = strings.Repeat("d", 600)
+	for i := 0; i < 30; i++ {
+		matched.Job.Skills = append(matched.Job.Skills, strings.Repeat("s", 60))
+	}
+	for i := 0; i < 30; i++ {
+		matched.AddMatch(fmt.Sprintf("Search %d", i), []string{strings.Repeat("k", 300)}, 0.1)
+	}
+
+	embed := FormatDiscordEmbed(matched)
+	if embed.Color != discordColorHourly {
+		t.Errorf("color = %#x, want hourly blue", embed.Color)
+	}
+	if utf8.RuneCountInString(embed.Title) != discordMaxTitle {
+		t.Errorf("title is %d characters, want %d", utf8.RuneCountInString(embed.Title), discordMaxTitle)
+	}
+	if len(embed.Fields) > discordMaxFields {
+		t.Errorf("got %d fields, want at most %d", len(embed.Fields), discordMaxFields)
+	}
+
+	total := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description) +
+		utf8.RuneCountInString(embed.Footer.Text)
+	for _, f := range embed.Fields {
+		if utf8.RuneCountInString(f.Value) > discordMaxFieldValue {
+			t.Errorf("field %s is %d characters", f.Name, utf8.RuneCountInString(f.Value))
+		}
+		total += utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+	}
+	if total > discordMaxEmbedTotal {
+		t.Errorf("embed is %d characters, want at most %d", total, discordMaxEmbedTotal)
+	}
+}
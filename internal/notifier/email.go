@@ -3,41 +3,110 @@ package notifier
 import (
 	"crypto/tls"
 	"fmt"
+	"net"
+	"net/mail"
 	"net/smtp"
-	"strings"
+	"strconv"
+	"time"
 
 	"jobradar/internal/config"
 	"jobradar/internal/model"
+	"jobradar/internal/templates"
 )
 
+// smtpTimeout bounds a whole SMTP session, from connecting to QUIT
+const smtpTimeout = 30 * time.Second
+
 // EmailNotifier sends notifications via email
 type EmailNotifier struct {
-	config config.EmailConfig
+	config  config.EmailConfig
+	subject *templates.Template
+	body    *templates.Template
+	text    *templates.Template
+	from    *mail.Address
+	to      []*mail.Address
+	cc      []*mail.Address
+	bcc     []*mail.Address
 }
 
-// NewEmail creates a new Email notifier
-func NewEmail(cfg config.EmailConfig) *EmailNotifier {
-	return &EmailNotifier{
-		config: cfg,
+// NewEmail creates a new Email notifier, failing if a template does not
+// load or an address does not parse
+func NewEmail(cfg config.EmailConfig) (*EmailNotifier, error) {
+	e := &EmailNotifier{config: cfg}
+
+	var err error
+	if e.subject, err = templates.EmailSubject(cfg.Subject); err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.ChannelName(), err)
+	}
+	if e.body, err = templates.EmailBody(cfg.Template); err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.ChannelName(), err)
 	}
+	if e.text, err = templates.EmailText(cfg.TextTemplate); err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.ChannelName(), err)
+	}
+
+	if e.from, err = mail.ParseAddress(cfg.Sender()); err != nil {
+		return nil, fmt.Errorf("%s: invalid sender %q: %w", cfg.ChannelName(), cfg.Sender(), err)
+	}
+	if cfg.FromName != "" {
+		e.from.Name = cfg.FromName
+	}
+	for _, list := range []struct {
+		dst *[]*mail.Address
+		src []string
+	}{
+		{&e.to, cfg.To},
+		{&e.cc, cfg.Cc},
+		{&e.bcc, cfg.Bcc},
+	} {
+		if *list.dst, err = parseAddresses(list.src); err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.ChannelName(), err)
+		}
+	}
+	return e, nil
 }
 
 // Name returns the notifier name
 func (e *EmailNotifier) Name() string {
-	return "email"
+	return e.config.ChannelName()
 }
 
 // Send sends a notification for a matched job
 func (e *EmailNotifier) Send(matched *model.MatchedJob) error {
-	subject := FormatEmailSubject(matched)
-	body := FormatEmailBody(matched)
-	return e.sendEmail(subject, body)
+	var content EmailContent
+	var err error
+	if content.Subject, err = e.subject.Render(matched); err != nil {
+		return err
+	}
+	if content.HTML, err = e.body.Render(matched); err != nil {
+		return err
+	}
+	if content.Text, err = e.text.Render(matched); err != nil {
+		return err
+	}
+	return e.sendEmail(content)
+}
+
+// SendDigest sends jobs as one or more summary emails
+func (e *EmailNotifier) SendDigest(jobs []*model.MatchedJob) (int, error) {
+	emails, sizes, err := FormatEmailDigest(jobs)
+	if err != nil {
+		return 0, err
+	}
+	return sendDigest(sizes, func(i int) error {
+		return e.sendEmail(emails[i])
+	})
 }
 
 // SendTest sends a test notification
 func (e *EmailNotifier) SendTest() error {
-	subject := "[JobRadar] Test Notification"
-	body := `<!DOCTYPE html>
+	return e.sendEmail(EmailContent{
+		Subject: "[JobRadar] Test Notification",
+		Text: `🔔 JobRadar Test Notification
+
+This is a test message to verify your email notification settings are working correctly.
+If you received this email, your configuration is correct!`,
+		HTML: `<!DOCTYPE html>
 <html>
 <head><meta charset="UTF-8"></head>
 <body>
@@ -50,83 +119,84 @@ func (e *EmailNotifier) SendTest() error {
         <li>✅ Email Delivery: Working</li>
     </ul>
 </body>
-</html>`
-	return e.sendEmail(subject, body)
+</html>`,
+	})
 }
 
-// sendEmail sends an email with the given subject and HTML body
-func (e *EmailNotifier) sendEmail(subject, body string) error {
-	from := e.config.Username
-	to := e.config.To
-
-	// Build email headers
-	headers := make(map[string]string)
-	headers["From"] = from
-	headers["To"] = to
-	headers["Subject"] = subject
-	headers["MIME-Version"] = "1.0"
-	headers["Content-Type"] = "text/html; charset=UTF-8"
-
-	// Build message
-	var msg strings.Builder
-	for k, v := range headers {
-		msg.WriteString(fmt.Sprintf("%s: %s\r\n", k, v))
+// sendEmail sends an email to every To, Cc and Bcc recipient
+func (e *EmailNotifier) sendEmail(content EmailContent) error {
+	now := time.Now()
+	msg := &mailMessage{
+		From:      e.from,
+		To:        e.to,
+		Cc:        e.cc,
+		Date:      now,
+		MessageID: newMessageID(e.from, now),
+		Content:   content,
 	}
-	msg.WriteString("\r\n")
-	msg.WriteString(body)
-
-	// Connect to SMTP server
-	addr := fmt.Sprintf("%s:%d", e.config.SMTPHost, e.config.SMTPPort)
-
-	// Use TLS for port 465, STARTTLS for other ports
-	if e.config.SMTPPort == 465 {
-		return e.sendWithTLS(addr, from, to, msg.String())
-	}
-	return e.sendWithSTARTTLS(addr, from, to, msg.String())
-}
-
-// sendWithSTARTTLS sends email using STARTTLS (port 587)
-func (e *EmailNotifier) sendWithSTARTTLS(addr, from, to, msg string) error {
-	auth := smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.SMTPHost)
-
-	err := smtp.SendMail(addr, auth, from, []string{to}, []byte(msg))
+	data, err := msg.Bytes()
 	if err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+		return err
 	}
 
-	return nil
+	var recipients []string
+	for _, list := range [][]*mail.Address{e.to, e.cc, e.bcc} {
+		for _, a := range list {
+			recipients = append(recipients, a.Address)
+		}
+	}
+	return e.deliver(recipients, data)
 }
 
-// sendWithTLS sends email using direct TLS (port 465)
-func (e *EmailNotifier) sendWithTLS(addr, from, to, msg string) error {
-	tlsConfig := &tls.Config{
-		ServerName: e.config.SMTPHost,
+// deliver runs an SMTP session sending one message
+func (e *EmailNotifier) deliver(recipients []string, msg []byte) error {
+	host := e.config.SMTPHost
+	addr := net.JoinHostPort(host, strconv.Itoa(e.config.SMTPPort))
+	security := e.config.SecurityMode()
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	var err error
+	if security == config.EmailSecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
 	}
-
-	conn, err := tls.Dial("tcp", addr, tlsConfig)
 	if err != nil {
 		return fmt.Errorf("failed to connect to SMTP server: %w", err)
 	}
-	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
 
-	client, err := smtp.NewClient(conn, e.config.SMTPHost)
+	client, err := smtp.NewClient(conn, host)
 	if err != nil {
+		conn.Close()
 		return fmt.Errorf("failed to create SMTP client: %w", err)
 	}
 	defer client.Close()
 
-	// Authenticate
-	auth := smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.SMTPHost)
-	if err := client.Auth(auth); err != nil {
-		return fmt.Errorf("SMTP authentication failed: %w", err)
+	if security == config.EmailSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("SMTP server does not support STARTTLS; set security to tls or none")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+
+	if auth := e.auth(); auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
 	}
 
-	// Set sender and recipient
-	if err := client.Mail(from); err != nil {
+	// Set sender and recipients
+	if err := client.Mail(e.from.Address); err != nil {
 		return fmt.Errorf("failed to set sender: %w", err)
 	}
-	if err := client.Rcpt(to); err != nil {
-		return fmt.Errorf("failed to set recipient: %w", err)
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("failed to set recipient %s: %w", rcpt, err)
+		}
 	}
 
 	// Send message body
@@ -134,12 +204,23 @@ func (e *EmailNotifier) sendWithTLS(addr, from, to, msg string) error {
 	if err != nil {
 		return fmt.Errorf("failed to open data writer: %w", err)
 	}
-	if _, err := w.Write([]byte(msg)); err != nil {
+	if _, err := w.Write(msg); err != nil {
 		return fmt.Errorf("failed to write message: %w", err)
 	}
 	if err := w.Close(); err != nil {
-		return fmt.Errorf("failed to close data writer: %w", err)
+		return fmt.Errorf("failed to send email: %w", err)
 	}
 
 	return client.Quit()
 }
+
+// auth returns the SMTP authentication for the configured mode, nil for none
+func (e *EmailNotifier) auth() smtp.Auth {
+	switch e.config.AuthMode() {
+	case config.EmailAuthPlain:
+		return smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.SMTPHost)
+	case config.EmailAuthLogin:
+		return &loginAuth{username: e.config.Username, password: e.config.Password, host: e.config.SMTPHost}
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package notifier
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"jobradar/internal/config"
+)
+
+// smtpSink is a minimal SMTP server recording the last message it received
+type smtpSink struct {
+	listener   net.Listener
+	auth       []string // AUTH exchange as sent by the client, decoded
+	from       string
+	recipients []string
+	data       string // With line endings normalized to \n
+	done       chan struct{}
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	s := &smtpSink{listener: l, done: make(chan struct{})}
+	t.Cleanup(func() { l.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *smtpSink) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpSink) serve() {
+	defer close(s.done)
+	c, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	conn := textproto.NewConn(c)
+	defer conn.Close()
+
+	decode := func(s string) string {
+		b, _ := base64.StdEncoding.DecodeString(s)
+		return string(b)
+	}
+	conn.PrintfLine("220 sink ready")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			conn.PrintfLine("250-sink")
+			conn.PrintfLine("250 AUTH PLAIN LOGIN")
+		case "AUTH":
+			mech, initial, _ := strings.Cut(arg, " ")
+			if mech == "PLAIN" {
+				s.auth = append(s.auth, mech, decode(initial))
+			} else {
+				s.auth = append(s.auth, mech)
+				for _, prompt := range []string{"Username:", "Password:"} {
+					conn.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte(prompt)))
+					answer, _ := conn.ReadLine()
+					s.auth = append(s.auth, decode(answer))
+				}
+			}
+			conn.PrintfLine("235 authenticated")
+		case "MAIL":
+			s.from = arg
+			conn.PrintfLine("250 ok")
+		case "RCPT":
+			s.recipients = append(s.recipients, arg)
+			conn.PrintfLine("250 ok")
+		case "DATA":
+			conn.PrintfLine("354 go ahead")
+			data, _ := io.ReadAll(conn.DotReader())
+			s.data = string(data)
+			conn.PrintfLine("250 queued")
+		case "QUIT":
+			conn.PrintfLine("221 bye")
+			return
+		default:
+			conn.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func testEmailConfig(port int) config.EmailConfig {
+	return config.EmailConfig{
+		Enabled:  true,
+		SMTPHost: "127.0.0.1",
+		SMTPPort: port,
+		Security: config.EmailSecurityNone,
+		Username: "bot@example.com",
+		Password: "secret",
+		FromName: "Jöb Radar",
+		To:       []string{"a@example.com", "Bea <b@example.com>"},
+		Cc:       []string{"c@example.com"},
+		Bcc:      []string{"d@example.com"},
+	}
+}
+
+func TestEmailNotifier_Send(t *testing.T) {
+	sink := newSMTPSink(t)
+	n, err := NewEmail(testEmailConfig(sink.port()))
+	if err != nil {
+		t.Fatalf("NewEmail() error = %v", err)
+	}
+	matched := testMatchedJob()
+	matched.Job.Title = "Développeur Go"
+	if err := n.Send(matched); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	<-sink.done
+
+	if got := strings.Join(sink.auth, "|"); got != "PLAIN|\x00bot@example.com\x00secret" {
+		t.Errorf("auth = %q", got)
+	}
+	if sink.from != "FROM:<bot@example.com>" {
+		t.Errorf("MAIL %s", sink.from)
+	}
+	want := "TO:<a@example.com>,TO:<b@example.com>,TO:<c@example.com>,TO:<d@example.com>"
+	if got := strings.Join(sink.recipients, ","); got != want {
+		t.Errorf("RCPT %s, want %s", got, want)
+	}
+
+	// Headers come in a fixed order, without Bcc
+	names := regexp.MustCompile(`(?m)^([A-Za-z-]+): `).FindAllStringSubmatch(strings.SplitN(sink.data, "\n\n", 2)[0], -1)
+	var order []string
+	for _, m := range names {
+		order = append(order, m[1])
+	}
+	if got := strings.Join(order, ","); got != "Date,From,To,Cc,Subject,Message-ID,MIME-Version,Content-Type" {
+		t.Errorf("headers = %s", got)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(sink.data))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if _, err := msg.Header.Date(); err != nil {
+		t.Errorf("Date: %v", err)
+	}
+	if id := msg.Header.Get("Message-ID"); !strings.HasSuffix(id, "@example.com>") {
+		t.Errorf("Message-ID = %q", id)
+	}
+	raw := msg.Header.Get("Subject")
+	subject, _ := new(mime.WordDecoder).DecodeHeader(raw)
+	if !strings.HasPrefix(raw, "=?UTF-8?q?") || subject != "[JobRadar] New Match: Développeur Go" {
+		t.Errorf("Subject = %q, decoded %q", raw, subject)
+	}
+	from, err := msg.Header.AddressList("From")
+	if err != nil || from[0].Name != "Jöb Radar" || from[0].Address != "bot@example.com" {
+		t.Errorf("From = %q (%v)", msg.Header.Get("From"), err)
+	}
+	if to := msg.Header.Get("To"); to != `<a@example.com>, "Bea" <b@example.com>` {
+		t.Errorf("To = %q", to)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q (%v)", msg.Header.Get("Content-Type"), err)
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for _, want := range []struct{ contentType, text string }{
+		{"text/plain; charset=UTF-8", "View job: https://www.upwork.com/jobs/~01a"},
+		{"text/html; charset=UTF-8", `<a href="https://www.upwork.com/jobs/~01a"`},
+	} {
+		part, err := parts.NextPart()
+		if err != nil {
+			t.Fatalf("NextPart() error = %v", err)
+		}
+		body, _ := io.ReadAll(part) // Quoted-printable is decoded by the reader
+		if ct := part.Header.Get("Content-Type"); ct != want.contentType {
+			t.Errorf("part Content-Type = %q, want %q", ct, want.contentType)
+		}
+		if !strings.Contains(string(body), want.text) {
+			t.Errorf("%s part does not contain %q:\n%s", want.contentType, want.text, body)
+		}
+	}
+}
+
+func TestEmailNotifier_Auth(t *testing.T) {
+	tests := []struct {
+		auth string
+		want string
+	}{
+		{config.EmailAuthLogin, "LOGIN|bot@example.com|secret"},
+		{config.EmailAuthNone, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.auth, func(t *testing.T) {
+			sink := newSMTPSink(t)
+			cfg := testEmailConfig(sink.port())
+			cfg.Auth = tt.auth
+			n, err := NewEmail(cfg)
+			if err != nil {
+				t.Fatalf("NewEmail() error = %v", err)
+			}
+			if err := n.SendTest(); err != nil {
+				t.Fatalf("SendTest() error = %v", err)
+			}
+			<-sink.done
+			if got := strings.Join(sink.auth, "|"); got != tt.want {
+				t.Errorf("auth = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmailNotifier_RequiresSTARTTLS(t *testing.T) {
+	sink := newSMTPSink(t)
+	cfg := testEmailConfig(sink.port())
+	cfg.Security = config.EmailSecurityStartTLS
+	n, err := NewEmail(cfg)
+	if err != nil {
+		t.Fatalf("NewEmail() error = %v", err)
+	}
+	if err := n.SendTest(); err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Errorf("SendTest() error = %v, want STARTTLS unsupported", err)
+	}
+	<-sink.done
+	if sink.data != "" {
+		t.Error("message sent without STARTTLS")
+	}
+}
+
+// TestEmailNotifier_SMTPSink sends through a real SMTP sink such as
+// MailHog when JOBRADAR_TEST_SMTP is set, e.g. to localhost:1025
+func TestEmailNotifier_SMTPSink(t *testing.T) {
+	addr := os.Getenv("JOBRADAR_TEST_SMTP")
+	if addr == "" {
+		t.Skip("JOBRADAR_TEST_SMTP not set")
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("JOBRADAR_TEST_SMTP: %v", err)
+	}
+	cfg := testEmailConfig(0)
+	cfg.SMTPHost = host
+	cfg.SMTPPort, _ = strconv.Atoi(port)
+	cfg.Auth = config.EmailAuthNone
+
+	n, err := NewEmail(cfg)
+	if err != nil {
+		t.Fatalf("NewEmail() error = %v", err)
+	}
+	if err := n.Send(testMatchedJob()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+}
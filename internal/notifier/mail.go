@@ -0,0 +1,151 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// EmailContent is the subject and alternative bodies of an email
+type EmailContent struct {
+	Subject string
+	Text    string // text/plain part
+	HTML    string // text/html part, preferred by mail clients
+}
+
+// mailMessage is an email ready to be written out as RFC 5322 text
+type mailMessage struct {
+	From      *mail.Address
+	To        []*mail.Address
+	Cc        []*mail.Address
+	Date      time.Time
+	MessageID string
+	Content   EmailContent
+}
+
+// Bytes writes the message with a multipart/alternative body. Headers are
+// always in the same order and non-ASCII ones are RFC 2047 encoded.
+func (m *mailMessage) Bytes() ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType, text string
+	}{
+		{"text/plain; charset=UTF-8", m.Content.Text},
+		{"text/html; charset=UTF-8", m.Content.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create message part: %w", err)
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.text)); err != nil {
+			return nil, fmt.Errorf("failed to write message part: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to write message part: %w", err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write message: %w", err)
+	}
+
+	var msg bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&msg, "%s: %s\r\n", name, value)
+	}
+	header("Date", m.Date.Format(time.RFC1123Z))
+	header("From", m.From.String())
+	header("To", joinAddresses(m.To))
+	if len(m.Cc) > 0 {
+		header("Cc", joinAddresses(m.Cc))
+	}
+	header("Subject", mime.QEncoding.Encode("UTF-8", m.Content.Subject))
+	header("Message-ID", m.MessageID)
+	header("MIME-Version", "1.0")
+	header("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary()))
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// joinAddresses formats addresses for a header, encoding display names
+func joinAddresses(addresses []*mail.Address) string {
+	list := make([]string, len(addresses))
+	for i, a := range addresses {
+		list[i] = a.String()
+	}
+	return strings.Join(list, ", ")
+}
+
+// parseAddresses parses a list of addresses such as "Jane <jane@example.com>"
+func parseAddresses(list []string) ([]*mail.Address, error) {
+	addresses := make([]*mail.Address, 0, len(list))
+	for _, s := range list {
+		a, err := mail.ParseAddress(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", s, err)
+		}
+		addresses = append(addresses, a)
+	}
+	return addresses, nil
+}
+
+// newMessageID returns a unique Message-ID in the sender's domain
+func newMessageID(from *mail.Address, now time.Time) string {
+	domain := "jobradar.local"
+	if at := strings.LastIndex(from.Address, "@"); at >= 0 && at < len(from.Address)-1 {
+		domain = from.Address[at+1:]
+	}
+	random := make([]byte, 8)
+	rand.Read(random)
+	return fmt.Sprintf("<%d.%s@%s>", now.UnixNano(), hex.EncodeToString(random), domain)
+}
+
+// loginAuth implements the LOGIN mechanism, which some servers such as
+// Office 365 offer instead of PLAIN. Like smtp.PlainAuth, it only sends
+// credentials over TLS or to localhost.
+type loginAuth struct {
+	username, password, host string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(string(fromServer))) {
+	case "username:":
+		return []byte(a.username), nil
+	case "password:":
+		return []byte(a.password), nil
+	}
+	return nil, fmt.Errorf("unexpected LOGIN challenge %q", fromServer)
+}
+
+// isLocalhost reports whether an SMTP host is the local machine
+func isLocalhost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
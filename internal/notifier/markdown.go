@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// splitMarkdown splits a MarkdownV2 message into parts of at most limit
+// characters. It cuts at the last blank line, line break or space in the
+// second half of a part, falling back to a hard cut, and never between a
+// backslash and the character it escapes.
+func splitMarkdown(text string, limit int) []string {
+	var parts []string
+	for utf8.RuneCountInString(text) > limit {
+		runes := []rune(text)
+		cut := markdownCut(runes[:limit])
+		if part := strings.TrimRight(string(runes[:cut]), " \n"); part != "" {
+			parts = append(parts, part)
+		}
+		text = strings.TrimLeft(string(runes[cut:]), " \n")
+	}
+	if text != "" || len(parts) == 0 {
+		parts = append(parts, text)
+	}
+	return parts
+}
+
+// markdownCut returns where to end a part holding at most the given runes
+func markdownCut(head []rune) int {
+	s := string(head)
+	half := len(string(head[:len(head)/2]))
+	for _, sep := range []string{"\n\n", "\n", " "} {
+		if i := strings.LastIndex(s, sep); i >= half {
+			return utf8.RuneCountInString(s[:i+len(sep)])
+		}
+	}
+	cut := len(head)
+	backslashes := 0
+	for i := cut - 1; i >= 0 && head[i] == '\\'; i-- {
+		backslashes++
+	}
+	if backslashes%2 == 1 {
+		cut--
+	}
+	return cut
+}
+
+// plainMarkdown turns MarkdownV2 into plain text for when Telegram rejects
+// the markup: escapes are undone, formatting marks dropped and links
+// written as "text (url)"
+func plainMarkdown(text string) string {
+	runes := []rune(text)
+	var sb strings.Builder
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '\\' && i+1 < len(runes):
+			i++
+			sb.WriteRune(runes[i])
+		case r == ']' && i+1 < len(runes) && runes[i+1] == '(':
+			end := i + 2
+			var url strings.Builder
+			for ; end < len(runes) && runes[end] != ')'; end++ {
+				if runes[end] == '\\' && end+1 < len(runes) {
+					end++
+				}
+				url.WriteRune(runes[end])
+			}
+			sb.WriteString(" (" + url.String() + ")")
+			i = end
+		case strings.ContainsRune("*_~|`[]", r):
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{"short", "*hello*", 10, []string{"*hello*"}},
+		{"blank line", "one two\n\nthree four", 14, []string{"one two", "three four"}},
+		{"line break", "one two\nthree four", 14, []string{"one two", "three four"}},
+		{"space", "one two three", 10, []string{"one two", "three"}},
+		{"early break ignored", "a\n" + strings.Repeat("x", 12), 10, []string{"a\n" + strings.Repeat("x", 8), "xxxx"}},
+		{"hard cut keeps escape", "ab\\.cd", 3, []string{"ab", "\\.c", "d"}},
+		{"runes", "日本語の文章", 3, []string{"日本語", "の文章"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitMarkdown(tt.text, tt.limit)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("splitMarkdown(%q, %d) = %q, want %q", tt.text, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainMarkdown(t *testing.T) {
+	md := "🔔 *New Job Match\\!*\n💰 $500 \\(Fixed\\) _C\\_\\_ok_\n🔗 [View Job](https://example.com/a\\)b\\\\c)"
+	want := "🔔 New Job Match!\n💰 $500 (Fixed) C__ok\n🔗 View Job (https://example.com/a)b\\c)"
+	if got := plainMarkdown(md); got != want {
+		t.Errorf("plainMarkdown() = %q, want %q", got, want)
+	}
+}
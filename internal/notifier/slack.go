@@ -0,0 +1,203 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"jobradar/internal/config"
+	"jobradar/internal/langdetect"
+	"jobradar/internal/model"
+	"jobradar/internal/templates"
+)
+
+// SlackNotifier sends notifications to Slack via an incoming webhook
+type SlackNotifier struct {
+	config config.SlackConfig
+	client *http.Client
+}
+
+// NewSlack creates a new Slack notifier
+func NewSlack(cfg config.SlackConfig) *SlackNotifier {
+	return &SlackNotifier{
+		config: cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the notifier name
+func (s *SlackNotifier) Name() string {
+	return s.config.ChannelName()
+}
+
+// Send sends a notification for a matched job
+func (s *SlackNotifier) Send(matched *model.MatchedJob) error {
+	return s.post(FormatSlackMessage(matched))
+}
+
+// SendDigest sends jobs as one or more summary messages
+func (s *SlackNotifier) SendDigest(jobs []*model.MatchedJob) (int, error) {
+	messages, sizes := FormatSlackDigest(jobs)
+	return sendDigest(sizes, func(i int) error {
+		return s.post(messages[i])
+	})
+}
+
+// SendTest sends a test notification
+func (s *SlackNotifier) SendTest() error {
+	text := "🔔 *JobRadar Test Notification*\nIf you see this message, your Slack webhook is configured correctly."
+	return s.post(&SlackMessage{
+		Text:   "JobRadar test notification",
+		Blocks: []SlackBlock{slackSection(text)},
+	})
+}
+
+// post sends a message to the webhook
+func (s *SlackNotifier) post(msg *SlackMessage) error {
+	if s.config.Channel != "" {
+		msg.Channel = s.config.Channel
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	resp, err := s.client.Post(s.config.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Slack answers "ok", or a short error code such as invalid_payload
+	reply, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode != http.StatusOK {
+		if text := strings.TrimSpace(string(reply)); text != "" {
+			return fmt.Errorf("slack webhook error (status %d): %s", resp.StatusCode, text)
+		}
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SlackMessage is an incoming webhook payload. Text is the fallback shown
+// in notifications when Blocks are rendered.
+type SlackMessage struct {
+	Channel string       `json:"channel,omitempty"`
+	Text    string       `json:"text"`
+	Blocks  []SlackBlock `json:"blocks,omitempty"`
+}
+
+// SlackBlock is a Block Kit layout block
+type SlackBlock struct {
+	Type     string       `json:"type"`
+	Text     *SlackText   `json:"text,omitempty"`
+	Fields   []*SlackText `json:"fields,omitempty"`
+	Elements []*SlackText `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Block Kit limits on section text and fields
+const (
+	slackMaxText   = 3000
+	slackMaxFields = 10
+)
+
+// FormatSlackMessage formats a job notification as Block Kit blocks
+func FormatSlackMessage(matched *model.MatchedJob) *SlackMessage {
+	job := matched.Job
+
+	proposals := "N/A"
+	if job.Proposals != nil {
+		proposals = fmt.Sprintf("%d", *job.Proposals)
+	}
+
+	fields := []*SlackText{
+		slackField("💰 Budget", job.BudgetDisplay()),
+		slackField("👥 Proposals", proposals),
+		slackField("⏰ Posted", job.PostedAgo()),
+		slackField("⭐ Score", fmt.Sprintf("%.2f", matched.MatchScore)),
+	}
+	if job.Language != "" {
+		fields = append(fields, slackField("🌐 Language", langdetect.Name(job.Language)))
+	}
+	if job.ClientCountry != "" {
+		fields = append(fields, slackField("📍 Client", job.ClientCountry))
+	}
+
+	blocks := []SlackBlock{
+		slackSection(fmt.Sprintf("🔔 *New Job Match!*\n*<%s|%s>*", job.URL, escapeSlack(job.Title))),
+		{Type: "section", Fields: fields},
+	}
+
+	if len(job.Skills) > 0 {
+		skills := job.Skills
+		if len(skills) > 10 {
+			skills = skills[:10]
+		}
+		blocks = append(blocks, slackSection("🏷️ *Skills:* "+escapeSlack(strings.Join(skills, ", "))))
+	}
+
+	if desc := templates.Truncate(500, job.Description); desc != "" {
+		blocks = append(blocks, slackSection(escapeSlack(desc)))
+	}
+
+	matches := []*SlackText{
+		{Type: "mrkdwn", Text: "✅ Matched: " + escapeSlack(strings.Join(matched.MatchedKeywords, ", "))},
+	}
+	if len(matched.Matches) > 1 {
+		for _, m := range matched.Matches {
+			matches = append(matches, &SlackText{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf("🔎 %s: %s", escapeSlack(m.SearchName), escapeSlack(strings.Join(m.MatchedKeywords, ", "))),
+			})
+		}
+	}
+	// Context blocks take at most 10 elements
+	if len(matches) > slackMaxFields {
+		matches = matches[:slackMaxFields]
+	}
+	blocks = append(blocks, SlackBlock{Type: "context", Elements: matches}, SlackBlock{Type: "divider"})
+
+	return &SlackMessage{
+		Text:   escapeSlack(fmt.Sprintf("New job match: %s (%s)", job.Title, job.BudgetDisplay())),
+		Blocks: blocks,
+	}
+}
+
+// slackSection builds a section block with mrkdwn text
+func slackSection(text string) SlackBlock {
+	if len(text) > slackMaxText {
+		// Cut on a rune boundary so the text stays valid UTF-8
+		n := slackMaxText - 3
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		text = text[:n] + "..."
+	}
+	return SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: text}}
+}
+
+// slackField builds a labelled section field
+func slackField(label, value string) *SlackText {
+	return &SlackText{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%s", label, escapeSlack(value))}
+}
+
+// escapeSlack escapes the characters Slack treats as control sequences
+func escapeSlack(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	s = strings.ReplaceAll(s, ">", "&gt;")
+	return s
+}
@@ -0,0 +1,104 @@
+package notifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+)
+
+func testMatchedJob() *model.MatchedJob {
+	budget := 500.0
+	proposals := 7
+	matched := model.NewMatchedJob(&model.Job{
+		ID:          "~01a",
+		Title:       "Go API <urgent> & more",
+		Description: "Build a REST API in Go",
+		URL:         "https://www.upwork.com/jobs/~01a",
+		JobType:     model.JobTypeFixed,
+		BudgetMax:   &budget,
+		Proposals:   &proposals,
+		Skills:      []string{"Go", "PostgreSQL"},
+	}, []string{"golang", "api"}, "Golang", 1)
+	matched.AddMatch("Backend", []string{"api"}, 0.5)
+	return matched
+}
+
+func TestSlackNotifier_Send(t *testing.T) {
+	var got SlackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("payload is not JSON: %v", err)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	n := NewSlack(config.SlackConfig{WebhookURL: server.URL, Channel: "#leads"})
+	if err := n.Send(testMatchedJob()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if got.Channel != "#leads" {
+		t.Errorf("channel = %q, want the override", got.Channel)
+	}
+	if len(got.Blocks) < 4 {
+		t.Fatalf("got %d blocks, want title, fields, skills, description and matches", len(got.Blocks))
+	}
+
+	title := got.Blocks[0].Text.Text
+	if !strings.Contains(title, "<https://www.upwork.com/jobs/~01a|Go API &lt;urgent&gt; &amp; more>") {
+		t.Errorf("title block = %q, want an escaped link", title)
+	}
+
+	var all strings.Builder
+	for _, b := range got.Blocks {
+		if b.Text != nil {
+			all.WriteString(b.Text.Text + "\n")
+		}
+		for _, f := range append(b.Fields, b.Elements...) {
+			all.WriteString(f.Text + "\n")
+		}
+	}
+	for _, want := range []string{"$500 (Fixed)", "*👥 Proposals*\n7", "Go, PostgreSQL", "golang, api", "1.00", "🔎 Backend: api"} {
+		if !strings.Contains(all.String(), want) {
+			t.Errorf("message does not contain %q:\n%s", want, all.String())
+		}
+	}
+}
+
+func TestSlackNotifier_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("no_service"))
+	}))
+	defer server.Close()
+
+	n := NewSlack(config.SlackConfig{WebhookURL: server.URL})
+	err := n.Send(testMatchedJob())
+	if err == nil || !strings.Contains(err.Error(), "no_service") {
+		t.Errorf("Send() error = %v, want the webhook error code", err)
+	}
+}
+
+func TestFormatSlackMessage_LongText(t *testing.T) {
+	matched := testMatchedJob()
+	matched.Job.Title = strings.Repeat("é", 2000)
+
+	msg := FormatSlackMessage(matched)
+	text := msg.Blocks[0].Text.Text
+	if len(text) > slackMaxText {
+		t.Errorf("section text is %d bytes, want at most %d", len(text), slackMaxText)
+	}
+	if !utf8.ValidString(text) || !strings.HasSuffix(text, "...") {
+		t.Errorf("long text was not cut on a rune boundary")
+	}
+}
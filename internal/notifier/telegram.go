@@ -3,89 +3,329 @@ package notifier
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"jobradar/internal/config"
 	"jobradar/internal/model"
+	"jobradar/internal/templates"
 )
 
-const telegramAPIURL = "https://api.telegram.org/bot%s/sendMessage"
+// TelegramAPIURL is the Bot API endpoint, formatted with the token and method
+const TelegramAPIURL = "https://api.telegram.org/bot%s/%s"
+
+// Rate limit handling: a 429 is retried after the wait Telegram asks for,
+// unless that wait is too long to block a check cycle on
+const (
+	telegramMaxRetries = 3
+	telegramMaxWait    = 30 * time.Second
+)
 
 // TelegramNotifier sends notifications via Telegram
 type TelegramNotifier struct {
-	config config.TelegramConfig
-	client *http.Client
+	config   config.TelegramConfig
+	client   *http.Client
+	template *templates.Template
+	apiURL   string
+	limiter  *chatLimiter
 }
 
-// NewTelegram creates a new Telegram notifier
-func NewTelegram(cfg config.TelegramConfig) *TelegramNotifier {
-	return &TelegramNotifier{
-		config: cfg,
-		client: &http.Client{Timeout: 10 * time.Second},
+// NewTelegram creates a new Telegram notifier, failing if the message
+// template does not load
+func NewTelegram(cfg config.TelegramConfig) (*TelegramNotifier, error) {
+	tmpl, err := templates.Telegram(cfg.Template)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.ChannelName(), err)
 	}
+	return &TelegramNotifier{
+		config:   cfg,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		template: tmpl,
+		apiURL:   TelegramAPIURL,
+		limiter:  telegramLimiter,
+	}, nil
 }
 
 // Name returns the notifier name
 func (t *TelegramNotifier) Name() string {
-	return "telegram"
+	return t.config.ChannelName()
 }
 
-// Send sends a notification for a matched job
+// Send sends a notification for a matched job, with action buttons when
+// the bot is enabled to answer them
 func (t *TelegramNotifier) Send(matched *model.MatchedJob) error {
-	message := FormatTelegramMessage(matched)
-	return t.sendMessage(message)
+	message, err := t.template.Render(matched)
+	if err != nil {
+		return err
+	}
+	var keyboard *TelegramKeyboard
+	if t.config.Bot.Enabled {
+		keyboard = JobKeyboard(matched.Job.ID, "")
+	}
+	return t.sendMessage(message, keyboard)
+}
+
+// SendDigest sends jobs as one or more summary messages
+func (t *TelegramNotifier) SendDigest(jobs []*model.MatchedJob) (int, error) {
+	messages, sizes := FormatTelegramDigest(jobs)
+	return sendDigest(sizes, func(i int) error {
+		return t.sendMessage(messages[i], nil)
+	})
 }
 
 // SendTest sends a test notification
 func (t *TelegramNotifier) SendTest() error {
 	message := FormatTestMessage()
-	return t.sendMessage(message)
+	return t.sendMessage(message, nil)
 }
 
-// sendMessage sends a message to Telegram
-func (t *TelegramNotifier) sendMessage(message string) error {
+// sendMessage sends a MarkdownV2 message, split into several when longer
+// than Telegram allows, with the keyboard under the last one. A part whose
+// markup Telegram rejects is sent again as plain text.
+func (t *TelegramNotifier) sendMessage(message string, keyboard *TelegramKeyboard) error {
+	parts := splitMarkdown(message, telegramMaxMessage)
+	for i, part := range parts {
+		var markup *TelegramKeyboard
+		if i == len(parts)-1 {
+			markup = keyboard
+		}
+		err := t.post(part, "MarkdownV2", markup)
+		var apiErr *telegramAPIError
+		if errors.As(err, &apiErr) && apiErr.parseError() {
+			err = t.post(plainMarkdown(part), "", markup)
+		}
+		if err != nil {
+			if len(parts) > 1 {
+				return fmt.Errorf("part %d of %d: %w", i+1, len(parts), err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// post sends one message, waiting for the chat's rate limit and retrying
+// when Telegram answers 429
+func (t *TelegramNotifier) post(text, parseMode string, keyboard *TelegramKeyboard) error {
 	payload := map[string]interface{}{
 		"chat_id":                  t.config.ChatID,
-		"text":                     message,
-		"parse_mode":               "MarkdownV2",
+		"text":                     text,
 		"disable_web_page_preview": false,
 	}
+	if parseMode != "" {
+		payload["parse_mode"] = parseMode
+	}
+	if keyboard != nil {
+		payload["reply_markup"] = keyboard
+	}
 
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	url := fmt.Sprintf(telegramAPIURL, t.config.BotToken)
+	url := fmt.Sprintf(t.apiURL, t.config.BotToken, "sendMessage")
+	for attempt := 1; ; attempt++ {
+		if err := t.limiter.wait(t.config.ChatID); err != nil {
+			return err
+		}
+		err := t.call(url, body)
+		var apiErr *telegramAPIError
+		if !errors.As(err, &apiErr) || apiErr.RetryAfter == 0 {
+			return err
+		}
+		t.limiter.delay(t.config.ChatID, apiErr.RetryAfter)
+		if attempt > telegramMaxRetries || apiErr.RetryAfter > telegramMaxWait {
+			return err
+		}
+	}
+}
+
+// call makes one sendMessage request
+func (t *TelegramNotifier) call(url string, body []byte) error {
 	resp, err := t.client.Post(url, "application/json", bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		var errResp struct {
-			OK          bool   `json:"ok"`
-			Description string `json:"description"`
+	var result struct {
+		OK          bool   `json:"ok"`
+		ErrorCode   int    `json:"error_code"`
+		Description string `json:"description"`
+		Parameters  struct {
+			RetryAfter float64 `json:"retry_after"`
+		} `json:"parameters"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("telegram API returned status %d", resp.StatusCode)
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && errResp.Description != "" {
-			return fmt.Errorf("telegram API error: %s", errResp.Description)
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !result.OK {
+		code := result.ErrorCode
+		if code == 0 {
+			code = resp.StatusCode
 		}
-		return fmt.Errorf("telegram API returned status %d", resp.StatusCode)
+		apiErr := &telegramAPIError{Code: code, Description: result.Description}
+		if code == http.StatusTooManyRequests {
+			apiErr.RetryAfter = time.Second
+			if result.Parameters.RetryAfter > 0 {
+				apiErr.RetryAfter = time.Duration(result.Parameters.RetryAfter * float64(time.Second))
+			}
+		}
+		return apiErr
 	}
+	return nil
+}
 
-	var result struct {
-		OK bool `json:"ok"`
+// telegramAPIError is an error answered by the Bot API
+type telegramAPIError struct {
+	Code        int
+	Description string
+	RetryAfter  time.Duration // Set on 429
+}
+
+func (e *telegramAPIError) Error() string {
+	switch {
+	case e.RetryAfter > 0:
+		return fmt.Sprintf("telegram API rate limited, retry after %s", e.RetryAfter)
+	case e.Description != "":
+		return fmt.Sprintf("telegram API error: %s", e.Description)
+	case e.Code == http.StatusOK:
+		return "telegram API returned ok=false"
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	return fmt.Sprintf("telegram API returned status %d", e.Code)
+}
+
+// parseError reports whether Telegram rejected the message's markup
+func (e *telegramAPIError) parseError() bool {
+	return e.Code == http.StatusBadRequest && strings.Contains(e.Description, "can't parse entities")
+}
+
+// chatLimiter spaces out messages to each chat. Telegram allows about one
+// message a second in a chat and 20 a minute in a group, and asks for
+// longer pauses with retry_after when a chat is flooded anyway.
+type chatLimiter struct {
+	mu            sync.Mutex
+	next          map[string]time.Time // When each chat may get its next message
+	interval      time.Duration
+	groupInterval time.Duration // For groups and channels, whose IDs are negative
+}
+
+// telegramLimiter is shared by all notifiers, so instances and digests
+// posting to the same chat take turns
+var telegramLimiter = newChatLimiter(time.Second, 3*time.Second)
+
+func newChatLimiter(interval, groupInterval time.Duration) *chatLimiter {
+	return &chatLimiter{
+		next:          make(map[string]time.Time),
+		interval:      interval,
+		groupInterval: groupInterval,
 	}
+}
 
-	if !result.OK {
-		return fmt.Errorf("telegram API returned ok=false")
+// wait blocks until a message may be sent to chatID and books the slot. It
+// fails instead when that is more than telegramMaxWait away.
+func (l *chatLimiter) wait(chatID string) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[chatID]
+	if at.Before(now) {
+		at = now
+	}
+	if wait := at.Sub(now); wait > telegramMaxWait {
+		l.mu.Unlock()
+		return fmt.Errorf("telegram chat %s rate limited, retry after %s", chatID, wait.Round(time.Second))
+	}
+	interval := l.interval
+	if strings.HasPrefix(chatID, "-") {
+		interval = l.groupInterval
 	}
+	l.next[chatID] = at.Add(interval)
+	l.mu.Unlock()
 
+	time.Sleep(time.Until(at))
 	return nil
 }
+
+// delay holds back messages to chatID for d, as asked by a 429
+func (l *chatLimiter) delay(chatID string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if at := time.Now().Add(d); at.After(l.next[chatID]) {
+		l.next[chatID] = at
+	}
+}
+
+// TelegramKeyboard is an inline keyboard attached to a message
+type TelegramKeyboard struct {
+	InlineKeyboard [][]TelegramButton `json:"inline_keyboard"`
+}
+
+// TelegramButton is an inline button that sends its callback data to the bot
+type TelegramButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+// Actions offered under a job alert, sent back as "<action>:<job ID>"
+const (
+	ActionSave       = "save"
+	ActionApplied    = "applied"
+	ActionDismiss    = "dismiss"
+	ActionIrrelevant = "irrelevant"
+)
+
+// jobActions are the alert buttons in display order, two per row
+var jobActions = []TelegramButton{
+	{Text: "💾 Save", CallbackData: ActionSave},
+	{Text: "📨 Applied", CallbackData: ActionApplied},
+	{Text: "🙈 Dismiss", CallbackData: ActionDismiss},
+	{Text: "👎 Not relevant", CallbackData: ActionIrrelevant},
+}
+
+// telegramMaxCallbackData is the Bot API limit on callback data, in bytes
+const telegramMaxCallbackData = 64
+
+// JobKeyboard returns the action buttons for a job alert, checking the
+// chosen action. It returns nil for job IDs too long for callback data.
+func JobKeyboard(jobID, chosen string) *TelegramKeyboard {
+	keyboard := &TelegramKeyboard{}
+	var row []TelegramButton
+	for _, action := range jobActions {
+		data := action.CallbackData + ":" + jobID
+		if len(data) > telegramMaxCallbackData {
+			return nil
+		}
+		text := action.Text
+		if action.CallbackData == chosen {
+			text = "✅ " + text
+		}
+		row = append(row, TelegramButton{Text: text, CallbackData: data})
+		if len(row) == 2 {
+			keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, row)
+			row = nil
+		}
+	}
+	return keyboard
+}
+
+// ParseJobAction splits the callback data of an alert button
+func ParseJobAction(data string) (action, jobID string, ok bool) {
+	action, jobID, found := strings.Cut(data, ":")
+	if !found || jobID == "" {
+		return "", "", false
+	}
+	for _, known := range jobActions {
+		if action == known.CallbackData {
+			return action, jobID, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,198 @@
+package notifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"jobradar/internal/config"
+)
+
+// newTestTelegram returns a notifier posting to a test server, without
+// waiting between messages
+func newTestTelegram(t *testing.T, serverURL string, cfg config.TelegramConfig) *TelegramNotifier {
+	t.Helper()
+	n, err := NewTelegram(cfg)
+	if err != nil {
+		t.Fatalf("NewTelegram() error = %v", err)
+	}
+	n.apiURL = serverURL + "/bot%s/%s"
+	n.limiter = newChatLimiter(0, 0)
+	return n
+}
+
+func TestTelegramNotifier_SendKeyboard(t *testing.T) {
+	var payload struct {
+		ChatID      string            `json:"chat_id"`
+		ReplyMarkup *TelegramKeyboard `json:"reply_markup"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/bottoken/sendMessage") {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Write([]byte(`{"ok": true, "result": {}}`))
+	}))
+	defer server.Close()
+
+	for _, enabled := range []bool{false, true} {
+		n := newTestTelegram(t, server.URL, config.TelegramConfig{
+			BotToken: "token",
+			ChatID:   "100",
+			Bot:      config.TelegramBotConfig{Enabled: enabled},
+		})
+
+		payload.ReplyMarkup = nil
+		if err := n.Send(testMatchedJob()); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		if (payload.ReplyMarkup != nil) != enabled {
+			t.Errorf("bot enabled = %v, reply_markup = %+v", enabled, payload.ReplyMarkup)
+		}
+	}
+
+	rows := payload.ReplyMarkup.InlineKeyboard
+	if len(rows) != 2 || rows[0][0].CallbackData != "save:~01a" {
+		t.Errorf("keyboard = %+v, want two rows of actions for the job", rows)
+	}
+}
+
+func TestTelegramNotifier_RateLimited(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"ok": false, "error_code": 429, "description": "Too Many Requests: retry after 0.05", "parameters": {"retry_after": 0.05}}`))
+			return
+		}
+		w.Write([]byte(`{"ok": true, "result": {}}`))
+	}))
+	defer server.Close()
+
+	n := newTestTelegram(t, server.URL, config.TelegramConfig{BotToken: "token", ChatID: "100"})
+	if err := n.Send(testMatchedJob()); err != nil {
+		t.Fatalf("Send() error = %v, want the retry to succeed", err)
+	}
+	if calls != 2 {
+		t.Errorf("API called %d times, want 2", calls)
+	}
+}
+
+func TestTelegramNotifier_RateLimitedTooLong(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"ok": false, "error_code": 429, "description": "Too Many Requests: retry after 120", "parameters": {"retry_after": 120}}`))
+	}))
+	defer server.Close()
+
+	n := newTestTelegram(t, server.URL, config.TelegramConfig{BotToken: "token", ChatID: "100"})
+	for i := 0; i < 2; i++ {
+		err := n.Send(testMatchedJob())
+		if err == nil || !strings.Contains(err.Error(), "rate limited") {
+			t.Errorf("Send() error = %v, want a rate limit error without waiting", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("API called %d times, want the second send held back by the limiter", calls)
+	}
+}
+
+func TestTelegramNotifier_SplitAndPlainFallback(t *testing.T) {
+	type message struct {
+		Text        string            `json:"text"`
+		ParseMode   string            `json:"parse_mode"`
+		ReplyMarkup *TelegramKeyboard `json:"reply_markup"`
+	}
+	var sent []message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m message
+		json.NewDecoder(r.Body).Decode(&m)
+		sent = append(sent, m)
+		if m.ParseMode != "" && strings.Contains(m.Text, "broken") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok": false, "error_code": 400, "description": "Bad Request: can't parse entities: Can't find end of Bold entity at byte offset 0"}`))
+			return
+		}
+		w.Write([]byte(`{"ok": true, "result": {}}`))
+	}))
+	defer server.Close()
+
+	n := newTestTelegram(t, server.URL, config.TelegramConfig{BotToken: "token", ChatID: "100"})
+	long := strings.Repeat("é", 3000) + "\n\n*broken [link](https://example.com/a\\)b) \\. " + strings.Repeat("ü", 3000)
+	if err := n.sendMessage(long, JobKeyboard("~01a", "")); err != nil {
+		t.Fatalf("sendMessage() error = %v", err)
+	}
+
+	if len(sent) != 3 {
+		t.Fatalf("sent %d messages, want 2 parts with the second retried as plain text", len(sent))
+	}
+	for i, m := range sent {
+		if n := utf8.RuneCountInString(m.Text); n > telegramMaxMessage {
+			t.Errorf("message %d has %d characters", i, n)
+		}
+		if (m.ReplyMarkup != nil) != (i >= 1) {
+			t.Errorf("message %d reply_markup = %v, want the keyboard on the last part only", i, m.ReplyMarkup)
+		}
+	}
+	if sent[1].ParseMode != "MarkdownV2" || sent[2].ParseMode != "" {
+		t.Errorf("parse modes = %q, %q, want MarkdownV2 then plain", sent[1].ParseMode, sent[2].ParseMode)
+	}
+	if want := "broken link (https://example.com/a)b) . ü"; !strings.HasPrefix(sent[2].Text, want) {
+		t.Errorf("plain text = %.60q, want prefix %q", sent[2].Text, want)
+	}
+}
+
+func TestChatLimiter(t *testing.T) {
+	l := newChatLimiter(20*time.Millisecond, 60*time.Millisecond)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		l.wait("100")
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond || elapsed >= 60*time.Millisecond*2 {
+		t.Errorf("three messages to a chat took %s, want about 40ms", elapsed)
+	}
+
+	start = time.Now()
+	l.wait("-100")
+	l.wait("-100")
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("two messages to a group took %s, want at least 60ms", elapsed)
+	}
+
+	l.delay("100", time.Minute)
+	if err := l.wait("100"); err == nil {
+		t.Error("wait() should fail while the chat is held back for longer than telegramMaxWait")
+	}
+}
+
+func TestParseJobAction(t *testing.T) {
+	tests := []struct {
+		data   string
+		action string
+		jobID  string
+		ok     bool
+	}{
+		{"applied:~01a", ActionApplied, "~01a", true},
+		{"irrelevant:~01a:b", ActionIrrelevant, "~01a:b", true},
+		{"delete:~01a", "", "", false},
+		{"save:", "", "", false},
+		{"save", "", "", false},
+	}
+	for _, tt := range tests {
+		action, jobID, ok := ParseJobAction(tt.data)
+		if action != tt.action || jobID != tt.jobID || ok != tt.ok {
+			t.Errorf("ParseJobAction(%q) = %q, %q, %v", tt.data, action, jobID, ok)
+		}
+	}
+
+	if JobKeyboard(strings.Repeat("x", 60), "") != nil {
+		t.Error("JobKeyboard() should skip job IDs too long for callback data")
+	}
+}
@@ -0,0 +1,183 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+)
+
+// Headers set on signed webhook requests. The signature is the hex
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret.
+const (
+	WebhookTimestampHeader = "X-JobRadar-Timestamp"
+	WebhookSignatureHeader = "X-JobRadar-Signature"
+)
+
+// WebhookPayload is the JSON body posted for a job, and the data passed
+// to a custom body template
+type WebhookPayload struct {
+	Event   string            `json:"event"`
+	Webhook string            `json:"webhook"`
+	SentAt  time.Time         `json:"sent_at"`
+	Job     *model.MatchedJob `json:"job"`
+}
+
+// WebhookNotifier posts matched jobs to an HTTP endpoint
+type WebhookNotifier struct {
+	config   config.WebhookConfig
+	client   *http.Client
+	template *template.Template
+}
+
+// NewWebhook creates a new webhook notifier, failing if the body template
+// does not parse
+func NewWebhook(cfg config.WebhookConfig) (*WebhookNotifier, error) {
+	w := &WebhookNotifier{
+		config: cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	if cfg.TimeoutSeconds > 0 {
+		w.client.Timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+
+	if cfg.BodyTemplate != "" {
+		tmpl, err := template.New(cfg.Name).Funcs(webhookFuncs).Parse(cfg.BodyTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("webhook %s: invalid body_template: %w", cfg.Name, err)
+		}
+		w.template = tmpl
+	}
+	return w, nil
+}
+
+// webhookFuncs are the functions available to body templates
+var webhookFuncs = template.FuncMap{
+	// json encodes a value, e.g. {{ json .Job.Job.Title }} for a quoted string
+	"json": func(v interface{}) (string, error) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(buf.String(), "\n"), nil
+	},
+}
+
+// Name returns the notifier name
+func (w *WebhookNotifier) Name() string {
+	return w.config.ChannelName()
+}
+
+// Send sends a notification for a matched job
+func (w *WebhookNotifier) Send(matched *model.MatchedJob) error {
+	return w.post(&WebhookPayload{Event: "job.matched", Job: matched})
+}
+
+// SendTest sends a test notification with a sample job, so custom body
+// templates render as they would for a real one
+func (w *WebhookNotifier) SendTest() error {
+	job := &model.Job{
+		ID:       "~0test",
+		Title:    "JobRadar test notification",
+		URL:      "https://www.upwork.com/",
+		JobType:  model.JobTypeFixed,
+		PostedAt: time.Now(),
+	}
+	return w.post(&WebhookPayload{Event: "test", Job: model.NewMatchedJob(job, []string{"test"}, "Test", 1)})
+}
+
+// post renders the payload and sends it, signing the body when a secret
+// is configured
+func (w *WebhookNotifier) post(payload *WebhookPayload) error {
+	payload.Webhook = w.config.Name
+	payload.SentAt = time.Now().UTC()
+
+	body, err := w.render(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "JobRadar")
+	for k, v := range w.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	if w.config.Secret != "" {
+		timestamp := strconv.FormatInt(payload.SentAt.Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(w.config.Secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !w.success(resp.StatusCode) {
+		reply, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		if text := strings.TrimSpace(string(reply)); text != "" {
+			return fmt.Errorf("webhook %s returned status %d: %s", w.config.Name, resp.StatusCode, text)
+		}
+		return fmt.Errorf("webhook %s returned status %d", w.config.Name, resp.StatusCode)
+	}
+	return nil
+}
+
+// render builds the request body from the template, or as JSON by default
+func (w *WebhookNotifier) render(payload *WebhookPayload) ([]byte, error) {
+	if w.template == nil {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := w.template.Execute(&buf, payload); err != nil {
+		return nil, fmt.Errorf("failed to render body template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// success reports whether a response status counts as delivered: any 2xx
+// unless success codes are configured
+func (w *WebhookNotifier) success(status int) bool {
+	if len(w.config.SuccessCodes) == 0 {
+		return status >= 200 && status < 300
+	}
+	for _, code := range w.config.SuccessCodes {
+		if status == code {
+			return true
+		}
+	}
+	return false
+}
+
+// SignWebhook returns the hex HMAC-SHA256 signature of a webhook body
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
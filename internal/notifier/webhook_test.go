@@ -0,0 +1,99 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"jobradar/internal/config"
+)
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	n, err := NewWebhook(config.WebhookConfig{
+		Name:    "crm",
+		URL:     server.URL,
+		Headers: map[string]string{"authorization": "Bearer token"},
+		Secret:  "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+	if err := n.Send(testMatchedJob()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if payload.Event != "job.matched" || payload.Webhook != "crm" || payload.Job.Job.ID != "~01a" {
+		t.Errorf("payload = %+v", payload)
+	}
+
+	if got := header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("Authorization = %q, want the configured header", got)
+	}
+	timestamp := header.Get(WebhookTimestampHeader)
+	want := "sha256=" + SignWebhook("s3cret", timestamp, body)
+	if timestamp == "" || header.Get(WebhookSignatureHeader) != want {
+		t.Errorf("signature = %q, want %q", header.Get(WebhookSignatureHeader), want)
+	}
+}
+
+func TestWebhookNotifier_Template(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	n, err := NewWebhook(config.WebhookConfig{
+		Name:         "chat",
+		URL:          server.URL,
+		BodyTemplate: `{"text": {{ json .Job.Job.Title }}, "score": {{ .Job.MatchScore }}}`,
+		SuccessCodes: []int{201},
+	})
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+	if err := n.Send(testMatchedJob()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if want := `{"text": "Go API <urgent> & more", "score": 1}`; body != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+}
+
+func TestWebhookNotifier_SuccessCodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("queued"))
+	}))
+	defer server.Close()
+
+	n, _ := NewWebhook(config.WebhookConfig{Name: "strict", URL: server.URL, SuccessCodes: []int{200}})
+	err := n.Send(testMatchedJob())
+	if err == nil || !strings.Contains(err.Error(), "202") {
+		t.Errorf("Send() error = %v, want 202 rejected", err)
+	}
+}
+
+func TestNewWebhook_InvalidTemplate(t *testing.T) {
+	if _, err := NewWebhook(config.WebhookConfig{Name: "bad", BodyTemplate: "{{ .Job"}); err == nil {
+		t.Error("NewWebhook() should reject a template that does not parse")
+	}
+}
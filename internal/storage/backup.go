@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat is the timestamp embedded in backup file names
+const backupTimeFormat = "20060102-150405"
+
+// ErrIntegrity is returned when a backup fails SQLite's integrity check
+var ErrIntegrity = errors.New("backup failed integrity check")
+
+// Backup copies the live database to dest using the SQLite online backup
+// API, so it is safe while the daemon is writing. The copy is written to a
+// temporary file and only renamed to dest once it passes an integrity
+// check, so a failed backup never looks like a good one to RotateBackups.
+func (s *SQLiteStore) Backup(dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("backup file %s already exists", dest)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	tmp := dest + ".tmp"
+	if err := s.backupTo(tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to move backup into place: %w", err)
+	}
+	return nil
+}
+
+// backupTo copies the live database to path and checks the copy
+func (s *SQLiteStore) backupTo(path string) error {
+	destDB, err := openSQLiteDB(fileURI(path, ""))
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer destDB.Close()
+
+	if err := copyDatabase(destDB, s.db); err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+
+	problems, err := integrityProblems(destDB)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrIntegrity, strings.Join(problems, "; "))
+	}
+	return destDB.Close()
+}
+
+// fileURI returns a "file:" URI for path with the given query, escaping the
+// path so that "?", "#" and "%" in it are not read as URI syntax
+func fileURI(path, query string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path), RawQuery: query}).String()
+}
+
+// Restore replaces the contents of the live database with a backup. The
+// backup must pass an integrity check and must not come from a newer
+// release; older backups are migrated after restoring.
+func (s *SQLiteStore) Restore(src string) error {
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("backup file not found: %w", err)
+	}
+
+	srcDB, err := openSQLiteDB(fileURI(src, "mode=ro"))
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer srcDB.Close()
+
+	problems, err := integrityProblems(srcDB)
+	if err != nil {
+		return fmt.Errorf("backup file is not a readable database: %w", err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrIntegrity, strings.Join(problems, "; "))
+	}
+
+	// Backups taken before schema versioning have no schema_version table
+	var tables int
+	err = srcDB.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('schema_version', 'jobs_seen')",
+	).Scan(&tables)
+	if err != nil {
+		return fmt.Errorf("failed to inspect backup: %w", err)
+	}
+	if tables == 0 {
+		return fmt.Errorf("backup file is not a JobRadar database")
+	}
+
+	var version int
+	err = srcDB.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
+	if err != nil && !strings.Contains(err.Error(), "no such table") {
+		return fmt.Errorf("failed to read backup schema version: %w", err)
+	}
+	if version > s.LatestVersion() {
+		return fmt.Errorf("backup has schema version %d, newer than this release supports (%d)", version, s.LatestVersion())
+	}
+
+	if err := copyDatabase(s.db, srcDB); err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	if err := s.Migrate(); err != nil {
+		return fmt.Errorf("failed to migrate restored database: %w", err)
+	}
+	return s.ensureSearchIndex()
+}
+
+// Vacuum rebuilds the database file to reclaim space left by deleted rows
+func (s *SQLiteStore) Vacuum() error {
+	if s.fts {
+		if _, err := s.exec("INSERT INTO jobs_fts (jobs_fts) VALUES ('optimize')"); err != nil {
+			return fmt.Errorf("failed to optimize search index: %w", err)
+		}
+	}
+	if _, err := s.exec("VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	return nil
+}
+
+// Check runs SQLite's integrity check and verifies the schema version and
+// search index, returning a description of each problem found
+func (s *SQLiteStore) Check() ([]string, error) {
+	problems, err := integrityProblems(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := s.checkSchema()
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, schema...)
+
+	indexed, err := s.hasSearchIndex()
+	if err != nil {
+		return nil, err
+	}
+	if indexed {
+		if _, err := s.db.Exec("INSERT INTO jobs_fts (jobs_fts) VALUES ('integrity-check')"); err != nil {
+			problems = append(problems, fmt.Sprintf("search index: %v", err))
+		}
+	}
+
+	return problems, nil
+}
+
+// integrityProblems runs PRAGMA integrity_check, returning nil when the
+// database is healthy. A file too damaged for the check to finish is
+// reported as a problem rather than an error.
+func integrityProblems(db *sql.DB) ([]string, error) {
+	rows, err := db.Query("PRAGMA integrity_check")
+	if isSQLiteCorrupt(err) {
+		return []string{err.Error()}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var msg string
+		if err := rows.Scan(&msg); err != nil {
+			return nil, fmt.Errorf("failed to read integrity check: %w", err)
+		}
+		if msg != "ok" {
+			problems = append(problems, msg)
+		}
+	}
+	if err := rows.Err(); isSQLiteCorrupt(err) {
+		return append(problems, err.Error()), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read integrity check: %w", err)
+	}
+	return problems, nil
+}
+
+// checkSchema reports a schema version that differs from this release
+func (s *sqlStore) checkSchema() ([]string, error) {
+	version, err := s.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	switch latest := s.LatestVersion(); {
+	case version < latest:
+		return []string{fmt.Sprintf("schema version %d is behind latest %d; run 'jobradar db migrate'", version, latest)}, nil
+	case version > latest:
+		return []string{fmt.Sprintf("schema version %d is newer than this release supports (%d)", version, latest)}, nil
+	}
+	return nil, nil
+}
+
+// BackupPath returns a timestamped backup file name in dir for the database at dbPath
+func BackupPath(dir, dbPath string, t time.Time) string {
+	base := strings.TrimSuffix(filepath.Base(dbPath), filepath.Ext(dbPath))
+	return filepath.Join(dir, base+"-"+t.Format(backupTimeFormat)+".db")
+}
+
+// RotateBackups deletes all but the newest keep backups of dbPath in dir,
+// returning the removed paths
+func RotateBackups(dir, dbPath string, keep int) ([]string, error) {
+	base := strings.TrimSuffix(filepath.Base(dbPath), filepath.Ext(dbPath))
+	matches, err := filepath.Glob(filepath.Join(dir, base+"-*.db"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Only count files named by BackupPath, so unrelated files are kept
+	var backups []string
+	for _, m := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(m), base+"-"), ".db")
+		if _, err := time.Parse(backupTimeFormat, stamp); err == nil {
+			backups = append(backups, m)
+		}
+	}
+
+	// Timestamps sort chronologically
+	sort.Strings(backups)
+
+	var removed []string
+	for len(backups) > keep {
+		if err := os.Remove(backups[0]); err != nil {
+			return removed, fmt.Errorf("failed to remove old backup: %w", err)
+		}
+		removed = append(removed, backups[0])
+		backups = backups[1:]
+	}
+	return removed, nil
+}
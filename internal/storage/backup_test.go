@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSQLiteStore_BackupAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSQLite(filepath.Join(dir, "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	if err := s.MarkSeen("~01before", "Before Backup", "https://example.com"); err != nil {
+		t.Fatalf("MarkSeen() error = %v", err)
+	}
+
+	// URI syntax in the file name must be taken literally
+	backup := filepath.Join(dir, "backups", "test-backup?#50%.db")
+	if err := s.Backup(backup); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+	if err := s.Backup(backup); err == nil {
+		t.Error("Backup() over an existing file should fail")
+	}
+	if _, err := os.Stat(backup + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary backup file left behind: %v", err)
+	}
+
+	if err := s.MarkSeen("~01after", "After Backup", "https://example.com"); err != nil {
+		t.Fatalf("MarkSeen() error = %v", err)
+	}
+
+	if err := s.Restore(backup); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	if seen, _ := s.IsSeen("~01before"); !seen {
+		t.Error("restored database is missing data from before the backup")
+	}
+	if seen, _ := s.IsSeen("~01after"); seen {
+		t.Error("restored database still has data written after the backup")
+	}
+
+	problems, err := s.Check()
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(problems) > 0 {
+		t.Errorf("Check() after restore = %v, want no problems", problems)
+	}
+
+	if err := s.Vacuum(); err != nil {
+		t.Errorf("Vacuum() error = %v", err)
+	}
+}
+
+func TestSQLiteStore_BackupFailureLeavesNoFile(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSQLite(filepath.Join(dir, "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	s.Close() // Copying from a closed database fails
+
+	backups := filepath.Join(dir, "backups")
+	if err := s.Backup(filepath.Join(backups, "test-20240101-000000.db")); err == nil {
+		t.Fatal("Backup() of a closed database should fail")
+	}
+	entries, _ := os.ReadDir(backups)
+	if len(entries) != 0 {
+		t.Errorf("backup directory holds %v after a failed backup, want it empty", entries)
+	}
+}
+
+func TestSQLiteStore_CheckUnmigrated(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+	s, err := NewSQLite(path)
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := s.MigrateTo(1); err != nil {
+		t.Fatalf("MigrateTo() error = %v", err)
+	}
+	s.Close()
+
+	s, err = OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	defer s.Close()
+
+	problems, err := s.Check()
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0], "behind latest") {
+		t.Errorf("Check() = %v, want the outdated schema reported", problems)
+	}
+	if version, _ := s.SchemaVersion(); version != 1 {
+		t.Errorf("schema version after Check() = %d, want it left at 1", version)
+	}
+}
+
+func TestSQLiteStore_CheckCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	s, err := NewSQLite(path)
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	s.Close()
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteAt(bytes.Repeat([]byte{0xff}, 4096), 2*4096)
+	f.Close()
+
+	s, err = OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	defer s.Close()
+
+	problems, err := s.Check()
+	if err != nil {
+		t.Fatalf("Check() error = %v, want the damage reported as problems", err)
+	}
+	if len(problems) == 0 {
+		t.Error("Check() found no problems in a damaged database")
+	}
+}
+
+func TestSQLiteStore_RestoreRejectsInvalidBackup(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewSQLite(filepath.Join(dir, "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	garbage := filepath.Join(dir, "garbage.db")
+	if err := os.WriteFile(garbage, []byte("not a database"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Restore(garbage); err == nil {
+		t.Error("Restore() of a non-database file should fail")
+	}
+
+	other, err := OpenSQLite(filepath.Join(dir, "other.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.db.Exec("CREATE TABLE notes (body TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	other.Close()
+	if err := s.Restore(filepath.Join(dir, "other.db")); err == nil {
+		t.Error("Restore() of a foreign database should fail")
+	}
+
+	if err := s.Restore(filepath.Join(dir, "missing.db")); err == nil {
+		t.Error("Restore() of a missing file should fail")
+	}
+}
+
+func TestRotateBackups(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	var paths []string
+	for i := 0; i < 5; i++ {
+		path := BackupPath(dir, "data/jobradar.db", start.Add(time.Duration(i)*time.Hour))
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	unrelated := filepath.Join(dir, "jobradar-manual.db")
+	if err := os.WriteFile(unrelated, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := RotateBackups(dir, "data/jobradar.db", 2)
+	if err != nil {
+		t.Fatalf("RotateBackups() error = %v", err)
+	}
+	if len(removed) != 3 || removed[0] != paths[0] {
+		t.Errorf("RotateBackups() removed %v, want the 3 oldest", removed)
+	}
+
+	for _, path := range append(paths[3:], unrelated) {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s should have been kept", filepath.Base(path))
+		}
+	}
+}
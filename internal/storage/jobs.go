@@ -0,0 +1,293 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+// jobColumns lists the jobs table columns in scan order
+const jobColumns = `job_id, title, description, url, job_type, budget_min, budget_max,
+	hourly_rate_min, hourly_rate_max, proposals, client_country, client_rating,
+	client_total_spent, client_total_hires, language, posted_at, fetched_at,
+	match_score, first_seen_at, last_seen_at`
+
+// SaveJobs stores fetched jobs with their match results. Existing jobs are
+// updated in place; once a job has matched a search, that match is kept
+// even if later runs no longer match it.
+func (s *sqlStore) SaveJobs(records []*model.JobRecord) error {
+	now := time.Now()
+	return s.transact(func(tx *sql.Tx) error {
+		for _, r := range records {
+			if err := s.saveJob(tx, r, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// saveJob upserts a single job record within a transaction
+func (s *sqlStore) saveJob(tx *sql.Tx, r *model.JobRecord, now time.Time) error {
+	job := r.Job
+
+	_, err := tx.Exec(s.rebind(`
+		INSERT INTO jobs (`+jobColumns+`, matched)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(job_id) DO UPDATE SET
+			title = excluded.title,
+			description = excluded.description,
+			url = excluded.url,
+			job_type = excluded.job_type,
+			budget_min = excluded.budget_min,
+			budget_max = excluded.budget_max,
+			hourly_rate_min = excluded.hourly_rate_min,
+			hourly_rate_max = excluded.hourly_rate_max,
+			proposals = excluded.proposals,
+			client_country = excluded.client_country,
+			client_rating = excluded.client_rating,
+			client_total_spent = excluded.client_total_spent,
+			client_total_hires = excluded.client_total_hires,
+			language = excluded.language,
+			posted_at = excluded.posted_at,
+			fetched_at = excluded.fetched_at,
+			matched = jobs.matched OR excluded.matched,
+			match_score = CASE WHEN excluded.match_score > jobs.match_score THEN excluded.match_score ELSE jobs.match_score END,
+			last_seen_at = excluded.last_seen_at
+	`), job.ID, job.Title, job.Description, job.URL, string(job.JobType),
+		job.BudgetMin, job.BudgetMax, job.HourlyRateMin, job.HourlyRateMax,
+		job.Proposals, job.ClientCountry, job.ClientRating,
+		job.ClientTotalSpent, job.ClientTotalHires, job.Language,
+		job.PostedAt, job.FetchedAt, r.MatchScore, now, now, r.Matched())
+	if err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+
+	if _, err := tx.Exec(s.rebind("DELETE FROM job_skills WHERE job_id = ?"), job.ID); err != nil {
+		return fmt.Errorf("failed to clear job skills: %w", err)
+	}
+	for i, skill := range job.Skills {
+		if _, err := tx.Exec(
+			s.rebind("INSERT INTO job_skills (job_id, skill, position) VALUES (?, ?, ?) ON CONFLICT DO NOTHING"),
+			job.ID, skill, i,
+		); err != nil {
+			return fmt.Errorf("failed to save job skill: %w", err)
+		}
+	}
+
+	matches := make(map[string]model.SearchMatch, len(r.Matches))
+	for _, m := range r.Matches {
+		matches[m.SearchName] = m
+	}
+	// A search that first returns the job in a later run goes after the
+	// earlier ones, which keep their positions
+	for _, search := range r.Searches {
+		m, matched := matches[search]
+		_, err := tx.Exec(s.rebind(`
+			INSERT INTO job_searches (job_id, search_name, matched, matched_keywords, match_score, position)
+			VALUES (?, ?, ?, ?, ?, (SELECT COUNT(*) FROM job_searches WHERE job_id = ?))
+			ON CONFLICT(job_id, search_name) DO UPDATE SET
+				matched_keywords = CASE WHEN excluded.matched THEN excluded.matched_keywords ELSE job_searches.matched_keywords END,
+				match_score = CASE WHEN excluded.matched THEN excluded.match_score ELSE job_searches.match_score END,
+				matched = job_searches.matched OR excluded.matched
+		`), job.ID, search, matched, strings.Join(m.MatchedKeywords, ","), m.MatchScore, job.ID)
+		if err != nil {
+			return fmt.Errorf("failed to save job search: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetJob retrieves a stored job by ID, returning nil if it does not exist
+func (s *sqlStore) GetJob(jobID string) (*model.JobRecord, error) {
+	r, err := scanJob(s.db.QueryRow(s.rebind("SELECT "+jobColumns+" FROM jobs WHERE job_id = ?"), jobID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if err := s.loadJobDetails(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// QueryJobs retrieves stored jobs matching the query, most recently seen first
+func (s *sqlStore) QueryJobs(q model.JobQuery) ([]*model.JobRecord, error) {
+	var records []*model.JobRecord
+	err := s.EachJob(q, func(r *model.JobRecord) error {
+		records = append(records, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// EachJob calls fn with each stored job matching the query, most recently
+// seen first, stopping at the first error. Skills and searches are loaded
+// on a second connection while the jobs are read.
+func (s *sqlStore) EachJob(q model.JobQuery, fn func(*model.JobRecord) error) error {
+	var conditions []string
+	var args []interface{}
+
+	if !q.Since.IsZero() {
+		conditions = append(conditions, "last_seen_at >= ?")
+		args = append(args, q.Since)
+	}
+	if !q.Until.IsZero() {
+		conditions = append(conditions, "last_seen_at < ?")
+		args = append(args, q.Until)
+	}
+	if q.MatchedOnly {
+		conditions = append(conditions, "matched")
+	}
+	if q.SearchName != "" {
+		conditions = append(conditions, "job_id IN (SELECT job_id FROM job_searches WHERE search_name = ?)")
+		args = append(args, q.SearchName)
+	}
+	if q.Skill != "" {
+		conditions = append(conditions, "job_id IN (SELECT job_id FROM job_skills WHERE LOWER(skill) = LOWER(?))")
+		args = append(args, q.Skill)
+	}
+
+	query := "SELECT " + jobColumns + " FROM jobs"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY last_seen_at DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	rows, err := s.db.Query(s.rebind(query), args...)
+	if err != nil {
+		return fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := scanJob(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan job: %w", err)
+		}
+		if err := s.loadJobDetails(r); err != nil {
+			return err
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query jobs: %w", err)
+	}
+	return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanJob scans a jobs row selected with jobColumns
+func scanJob(row rowScanner) (*model.JobRecord, error) {
+	r := &model.JobRecord{Job: &model.Job{}}
+	if err := scanJobWith(row, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// scanJobWith scans jobColumns into r, followed by any extra columns
+func scanJobWith(row rowScanner, r *model.JobRecord, extra ...interface{}) error {
+	job := r.Job
+
+	var jobType, country, language sql.NullString
+	var postedAt, fetchedAt sql.NullTime
+	dest := []interface{}{
+		&job.ID, &job.Title, &job.Description, &job.URL, &jobType,
+		&job.BudgetMin, &job.BudgetMax, &job.HourlyRateMin, &job.HourlyRateMax,
+		&job.Proposals, &country, &job.ClientRating,
+		&job.ClientTotalSpent, &job.ClientTotalHires, &language,
+		&postedAt, &fetchedAt, &r.MatchScore, &r.FirstSeenAt, &r.LastSeenAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	job.JobType = model.JobType(jobType.String)
+	job.ClientCountry = country.String
+	job.Language = language.String
+	job.PostedAt = postedAt.Time
+	job.FetchedAt = fetchedAt.Time
+	return nil
+}
+
+// loadJobDetails loads the skills and search results of a job record
+func (s *sqlStore) loadJobDetails(r *model.JobRecord) error {
+	rows, err := s.db.Query(s.rebind("SELECT skill FROM job_skills WHERE job_id = ? ORDER BY position"), r.Job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get job skills: %w", err)
+	}
+	for rows.Next() {
+		var skill string
+		if err := rows.Scan(&skill); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan job skill: %w", err)
+		}
+		r.Job.Skills = append(r.Job.Skills, skill)
+	}
+	rows.Close()
+
+	rows, err = s.db.Query(s.rebind(`
+		SELECT search_name, matched, COALESCE(matched_keywords, ''), match_score
+		FROM job_searches
+		WHERE job_id = ?
+		ORDER BY position`), r.Job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get job searches: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m model.SearchMatch
+		var matched bool
+		var keywords string
+		if err := rows.Scan(&m.SearchName, &matched, &keywords, &m.MatchScore); err != nil {
+			return fmt.Errorf("failed to scan job search: %w", err)
+		}
+		r.Searches = append(r.Searches, m.SearchName)
+		if matched {
+			if keywords != "" {
+				m.MatchedKeywords = strings.Split(keywords, ",")
+			}
+			r.Matches = append(r.Matches, m)
+		}
+	}
+	return rows.Err()
+}
+
+// cleanupJobs removes jobs not seen since the cutoff along with their details
+func (s *sqlStore) cleanupJobs(cutoff time.Time) error {
+	if _, err := s.exec(s.rebind("DELETE FROM jobs WHERE last_seen_at < ?"), cutoff); err != nil {
+		return fmt.Errorf("failed to cleanup jobs: %w", err)
+	}
+
+	if _, err := s.exec("DELETE FROM job_skills WHERE job_id NOT IN (SELECT job_id FROM jobs)"); err != nil {
+		return fmt.Errorf("failed to cleanup job_skills: %w", err)
+	}
+
+	if _, err := s.exec("DELETE FROM job_searches WHERE job_id NOT IN (SELECT job_id FROM jobs)"); err != nil {
+		return fmt.Errorf("failed to cleanup job_searches: %w", err)
+	}
+
+	return nil
+}
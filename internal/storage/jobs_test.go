@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+)
+
+func TestSQLiteStore_SaveJobs(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	budget := 800.0
+	matched := &model.JobRecord{
+		Job: &model.Job{
+			ID:        "~01a",
+			Title:     "Go API",
+			JobType:   model.JobTypeFixed,
+			BudgetMax: &budget,
+			Skills:    []string{"PostgreSQL", "Go"},
+			Language:  "en",
+		},
+		Searches:   []string{"Golang", "Python"},
+		Matches:    []model.SearchMatch{{SearchName: "Golang", MatchedKeywords: []string{"go", "api"}, MatchScore: 0.5}},
+		MatchScore: 0.5,
+	}
+	if err := s.SaveJobs([]*model.JobRecord{matched}); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+
+	got, err := s.GetJob("~01a")
+	if err != nil || got == nil {
+		t.Fatalf("GetJob() = %v, %v", got, err)
+	}
+	if got.Job.Title != "Go API" || got.Job.BudgetMax == nil || *got.Job.BudgetMax != budget || got.Job.Language != "en" {
+		t.Errorf("job = %+v, want the saved fields", got.Job)
+	}
+	if strings.Join(got.Job.Skills, ",") != "PostgreSQL,Go" {
+		t.Errorf("skills = %v", got.Job.Skills)
+	}
+	if strings.Join(got.Searches, ",") != "Golang,Python" {
+		t.Errorf("searches = %v, want both searches that returned the job", got.Searches)
+	}
+	if len(got.Matches) != 1 || got.Matches[0].SearchName != "Golang" || strings.Join(got.Matches[0].MatchedKeywords, ",") != "go,api" {
+		t.Errorf("matches = %+v, want only the Golang match", got.Matches)
+	}
+	firstSeen := got.FirstSeenAt
+
+	// A later run that no longer matches updates the job but keeps its match
+	time.Sleep(10 * time.Millisecond)
+	again := &model.JobRecord{
+		Job:      &model.Job{ID: "~01a", Title: "Go API (updated)", Skills: []string{"Go"}},
+		Searches: []string{"Rust", "Golang"},
+	}
+	if err := s.SaveJobs([]*model.JobRecord{again}); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+
+	got, err = s.GetJob("~01a")
+	if err != nil || got == nil {
+		t.Fatalf("GetJob() = %v, %v", got, err)
+	}
+	if got.Job.Title != "Go API (updated)" || strings.Join(got.Job.Skills, ",") != "Go" {
+		t.Errorf("job = %q with skills %v, want the updated title and skills", got.Job.Title, got.Job.Skills)
+	}
+	if strings.Join(got.Searches, ",") != "Golang,Python,Rust" {
+		t.Errorf("searches = %v, want a new search after the earlier ones", got.Searches)
+	}
+	if !got.Matched() || got.MatchScore != 0.5 || len(got.Matches) != 1 {
+		t.Errorf("match = %v (score %v), want the earlier match kept", got.Matches, got.MatchScore)
+	}
+	if !got.FirstSeenAt.Equal(firstSeen) || !got.LastSeenAt.After(firstSeen) {
+		t.Errorf("first seen %v, last seen %v, want first seen kept and last seen moved on", got.FirstSeenAt, got.LastSeenAt)
+	}
+
+	if missing, err := s.GetJob("~01missing"); missing != nil || err != nil {
+		t.Errorf("GetJob() of a missing job = %v, %v, want nil, nil", missing, err)
+	}
+}
+
+func TestSQLiteStore_QueryJobs(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	records := []*model.JobRecord{
+		{
+			Job:      &model.Job{ID: "~01a", Title: "Go API", Skills: []string{"Go"}},
+			Searches: []string{"Golang"},
+			Matches:  []model.SearchMatch{{SearchName: "Golang", MatchedKeywords: []string{"go"}, MatchScore: 1}},
+		},
+		{
+			Job:      &model.Job{ID: "~01b", Title: "Scraper", Skills: []string{"Python"}},
+			Searches: []string{"Golang"},
+		},
+		{
+			Job:      &model.Job{ID: "~01c", Title: "Django app", Skills: []string{"python", "Django"}},
+			Searches: []string{"Python"},
+			Matches:  []model.SearchMatch{{SearchName: "Python", MatchedKeywords: []string{"django"}, MatchScore: 1}},
+		},
+	}
+	if err := s.SaveJobs(records); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query model.JobQuery
+		want  int
+	}{
+		{"all", model.JobQuery{}, 3},
+		{"search", model.JobQuery{SearchName: "Golang"}, 2},
+		{"skill ignores case", model.JobQuery{Skill: "PYTHON"}, 2},
+		{"matched only", model.JobQuery{MatchedOnly: true}, 2},
+		{"search and matched", model.JobQuery{SearchName: "Golang", MatchedOnly: true}, 1},
+		{"until", model.JobQuery{Until: time.Now().Add(-time.Hour)}, 0},
+		{"limit", model.JobQuery{Limit: 1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.QueryJobs(tt.query)
+			if err != nil {
+				t.Fatalf("QueryJobs() error = %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("QueryJobs() returned %d jobs, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteStore_CleanupJobs(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	records := []*model.JobRecord{
+		{Job: &model.Job{ID: "~01old", Title: "Old", Skills: []string{"Go"}}, Searches: []string{"Golang"}},
+		{Job: &model.Job{ID: "~01new", Title: "New", Skills: []string{"Go"}}, Searches: []string{"Golang"}},
+	}
+	if err := s.SaveJobs(records); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+	if _, err := s.db.Exec("UPDATE jobs SET last_seen_at = ? WHERE job_id = ?", time.Now().AddDate(0, 0, -10), "~01old"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Cleanup(config.RetentionConfig{Jobs: 7}); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	if old, _ := s.GetJob("~01old"); old != nil {
+		t.Error("job not seen within the retention period was kept")
+	}
+	if fresh, _ := s.GetJob("~01new"); fresh == nil {
+		t.Error("recently seen job was deleted")
+	}
+	for _, table := range []string{"job_skills", "job_searches"} {
+		var n int
+		if err := s.db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE job_id = ?", "~01old").Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		if n != 0 {
+			t.Errorf("%s kept %d rows of the deleted job", table, n)
+		}
+	}
+}
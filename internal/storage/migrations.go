@@ -0,0 +1,429 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migration is a single versioned schema change. Up statements must be safe
+// to run against databases created before schema versioning existed, so the
+// early steps use IF NOT EXISTS.
+type migration struct {
+	Version int
+	Name    string
+	Up      []string
+	Down    []string
+}
+
+// MigrationStatus describes a migration and whether it has been applied
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// sqliteMigrations lists all SQLite schema changes in order. Append new steps
+// to the end, keep versions in step with postgresMigrations, and never edit
+// a step that has been released.
+var sqliteMigrations = []migration{
+	{
+		Version: 1,
+		Name:    "initial schema",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS jobs_seen (
+				job_id VARCHAR(100) PRIMARY KEY,
+				job_title VARCHAR(500),
+				job_url VARCHAR(1000),
+				first_seen_at TIMESTAMP NOT NULL,
+				notified BOOLEAN DEFAULT FALSE,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_jobs_seen_created ON jobs_seen(created_at)`,
+
+			`CREATE TABLE IF NOT EXISTS notify_records (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				job_id VARCHAR(100) NOT NULL,
+				job_title VARCHAR(500),
+				job_url VARCHAR(1000),
+				search_name VARCHAR(100),
+				matched_keywords TEXT,
+				notify_channel VARCHAR(50) NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				error_message TEXT,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				sent_at TIMESTAMP
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_notify_records_job ON notify_records(job_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_notify_records_created ON notify_records(created_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_notify_records_status ON notify_records(status)`,
+
+			`CREATE TABLE IF NOT EXISTS run_logs (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				started_at TIMESTAMP NOT NULL,
+				finished_at TIMESTAMP,
+				jobs_fetched INT DEFAULT 0,
+				jobs_matched INT DEFAULT 0,
+				jobs_notified INT DEFAULT 0,
+				jobs_skipped INT DEFAULT 0,
+				error_message TEXT,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_run_logs_started ON run_logs(started_at)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS run_logs`,
+			`DROP TABLE IF EXISTS notify_records`,
+			`DROP TABLE IF EXISTS jobs_seen`,
+		},
+	},
+	{
+		Version: 2,
+		Name:    "search matches",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS search_matches (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				job_id VARCHAR(100) NOT NULL,
+				search_name VARCHAR(100) NOT NULL,
+				matched_keywords TEXT,
+				match_score REAL DEFAULT 0,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_search_matches_job ON search_matches(job_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_search_matches_search ON search_matches(search_name)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS search_matches`,
+		},
+	},
+	{
+		Version: 3,
+		Name:    "job records",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS jobs (
+				job_id VARCHAR(100) PRIMARY KEY,
+				title VARCHAR(500),
+				description TEXT,
+				url VARCHAR(1000),
+				job_type VARCHAR(20),
+				budget_min REAL,
+				budget_max REAL,
+				hourly_rate_min REAL,
+				hourly_rate_max REAL,
+				proposals INT,
+				client_country VARCHAR(100),
+				client_rating REAL,
+				client_total_spent REAL,
+				client_total_hires INT,
+				language VARCHAR(10),
+				posted_at TIMESTAMP,
+				fetched_at TIMESTAMP,
+				matched BOOLEAN DEFAULT FALSE,
+				match_score REAL DEFAULT 0,
+				first_seen_at TIMESTAMP NOT NULL,
+				last_seen_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_jobs_posted ON jobs(posted_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_jobs_last_seen ON jobs(last_seen_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_jobs_matched ON jobs(matched)`,
+
+			`CREATE TABLE IF NOT EXISTS job_skills (
+				job_id VARCHAR(100) NOT NULL,
+				skill VARCHAR(200) NOT NULL,
+				PRIMARY KEY (job_id, skill)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_skills_skill ON job_skills(skill)`,
+
+			`CREATE TABLE IF NOT EXISTS job_searches (
+				job_id VARCHAR(100) NOT NULL,
+				search_name VARCHAR(100) NOT NULL,
+				matched BOOLEAN DEFAULT FALSE,
+				matched_keywords TEXT,
+				match_score REAL DEFAULT 0,
+				PRIMARY KEY (job_id, search_name)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_searches_search ON job_searches(search_name)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS job_searches`,
+			`DROP TABLE IF EXISTS job_skills`,
+			`DROP TABLE IF EXISTS jobs`,
+		},
+	},
+	{
+		Version: 4,
+		Name:    "run log daily aggregates",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS run_log_daily (
+				day VARCHAR(10) PRIMARY KEY,
+				runs INT NOT NULL DEFAULT 0,
+				failed_runs INT NOT NULL DEFAULT 0,
+				jobs_fetched INT NOT NULL DEFAULT 0,
+				jobs_matched INT NOT NULL DEFAULT 0,
+				jobs_notified INT NOT NULL DEFAULT 0,
+				jobs_skipped INT NOT NULL DEFAULT 0,
+				duration_seconds REAL NOT NULL DEFAULT 0,
+				first_started_at TIMESTAMP NOT NULL,
+				last_started_at TIMESTAMP NOT NULL
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS run_log_daily`,
+		},
+	},
+	{
+		Version: 5,
+		Name:    "job tracking",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS job_tracking (
+				job_id VARCHAR(100) PRIMARY KEY,
+				job_title VARCHAR(500),
+				job_url VARCHAR(1000),
+				search_names TEXT,
+				keywords TEXT,
+				status VARCHAR(20) NOT NULL,
+				bid REAL,
+				connects INT NOT NULL DEFAULT 0,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL,
+				applied_at TIMESTAMP,
+				closed_at TIMESTAMP
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_tracking_status ON job_tracking(status)`,
+
+			`CREATE TABLE IF NOT EXISTS job_status_changes (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				job_id VARCHAR(100) NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				changed_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_status_changes_job ON job_status_changes(job_id)`,
+
+			`CREATE TABLE IF NOT EXISTS job_notes (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				job_id VARCHAR(100) NOT NULL,
+				body TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_notes_job ON job_notes(job_id)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS job_notes`,
+			`DROP TABLE IF EXISTS job_status_changes`,
+			`DROP TABLE IF EXISTS job_tracking`,
+		},
+	},
+	{
+		Version: 6,
+		Name:    "run source results",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS run_source_results (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				run_id BIGINT NOT NULL,
+				source VARCHAR(20) NOT NULL,
+				search_name VARCHAR(100),
+				keyword VARCHAR(200),
+				started_at TIMESTAMP NOT NULL,
+				duration_seconds REAL NOT NULL DEFAULT 0,
+				fetched INT NOT NULL DEFAULT 0,
+				http_status INT,
+				error_message TEXT
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_run_source_results_run ON run_source_results(run_id)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS run_source_results`,
+		},
+	},
+	{
+		Version: 7,
+		Name:    "notification outbox",
+		Up: []string{
+			`ALTER TABLE notify_records ADD COLUMN attempts INT NOT NULL DEFAULT 0`,
+			`ALTER TABLE notify_records ADD COLUMN next_attempt_at TIMESTAMP`,
+			`ALTER TABLE notify_records ADD COLUMN payload TEXT`,
+			`UPDATE notify_records SET attempts = 1`,
+			`CREATE INDEX IF NOT EXISTS idx_notify_records_due ON notify_records(status, next_attempt_at)`,
+		},
+		Down: []string{
+			`DROP INDEX IF EXISTS idx_notify_records_due`,
+			`ALTER TABLE notify_records DROP COLUMN payload`,
+			`ALTER TABLE notify_records DROP COLUMN next_attempt_at`,
+			`ALTER TABLE notify_records DROP COLUMN attempts`,
+		},
+	},
+	{
+		Version: 8,
+		Name:    "job detail positions",
+		Up: []string{
+			`ALTER TABLE job_skills ADD COLUMN position INT NOT NULL DEFAULT 0`,
+			`ALTER TABLE job_searches ADD COLUMN position INT NOT NULL DEFAULT 0`,
+			`UPDATE job_skills SET position = (
+				SELECT COUNT(*) FROM job_skills o WHERE o.job_id = job_skills.job_id AND o.rowid < job_skills.rowid
+			)`,
+			`UPDATE job_searches SET position = (
+				SELECT COUNT(*) FROM job_searches o WHERE o.job_id = job_searches.job_id AND o.rowid < job_searches.rowid
+			)`,
+		},
+		Down: []string{
+			`ALTER TABLE job_searches DROP COLUMN position`,
+			`ALTER TABLE job_skills DROP COLUMN position`,
+		},
+	},
+}
+
+// LatestVersion returns the schema version of the newest migration
+func (s *sqlStore) LatestVersion() int {
+	migrations := s.dialect.migrations
+	return migrations[len(migrations)-1].Version
+}
+
+// Migrate applies all pending migrations
+func (s *sqlStore) Migrate() error {
+	return s.MigrateTo(s.LatestVersion())
+}
+
+// MigrateTo migrates the schema up or down to the target version.
+// Each step runs in its own transaction together with its version record.
+func (s *sqlStore) MigrateTo(target int) error {
+	if target < 0 || target > s.LatestVersion() {
+		return fmt.Errorf("invalid target version %d (latest is %d)", target, s.LatestVersion())
+	}
+
+	if err := s.ensureVersionTable(); err != nil {
+		return err
+	}
+
+	current, err := s.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	migrations := s.dialect.migrations
+
+	// Upgrade
+	for _, m := range migrations {
+		if m.Version <= current || m.Version > target {
+			continue
+		}
+		if err := s.applyMigration(m.Version, true, m.Up, func(tx *sql.Tx) error {
+			_, err := tx.Exec(
+				s.rebind("INSERT INTO schema_version (version, name, applied_at) VALUES (?, ?, ?)"),
+				m.Version, m.Name, time.Now(),
+			)
+			return err
+		}); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+	}
+
+	// Downgrade
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.Version > current || m.Version <= target {
+			continue
+		}
+		if err := s.applyMigration(m.Version, false, m.Down, func(tx *sql.Tx) error {
+			_, err := tx.Exec(s.rebind("DELETE FROM schema_version WHERE version = ?"), m.Version)
+			return err
+		}); err != nil {
+			return fmt.Errorf("rollback of migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// SchemaVersion returns the current schema version, 0 if none applied
+func (s *sqlStore) SchemaVersion() (int, error) {
+	if err := s.ensureVersionTable(); err != nil {
+		return 0, err
+	}
+
+	var version int
+	err := s.db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	return version, nil
+}
+
+// MigrationStatus lists all known migrations and when they were applied
+func (s *sqlStore) MigrationStatus() ([]MigrationStatus, error) {
+	if err := s.ensureVersionTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query("SELECT version, applied_at FROM schema_version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration status: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan migration status: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get migration status: %w", err)
+	}
+
+	result := make([]MigrationStatus, 0, len(s.dialect.migrations))
+	for _, m := range s.dialect.migrations {
+		status := MigrationStatus{Version: m.Version, Name: m.Name}
+		if t, ok := applied[m.Version]; ok {
+			status.AppliedAt = &t
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+// ensureVersionTable creates the schema_version table if needed
+func (s *sqlStore) ensureVersionTable() error {
+	_, err := s.exec(s.dialect.versionTable)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_version table: %w", err)
+	}
+	return nil
+}
+
+// applyMigration runs the statements and version bookkeeping in one
+// transaction. Another process may have applied (or rolled back) the same
+// step since the version was read, so the version is read again once the
+// transaction holds the migration lock and the step is skipped when the
+// version table already reflects it.
+func (s *sqlStore) applyMigration(version int, up bool, statements []string, record func(tx *sql.Tx) error) error {
+	return s.transact(func(tx *sql.Tx) error {
+		if s.dialect.migrationLock != "" {
+			if _, err := tx.Exec(s.dialect.migrationLock); err != nil {
+				return fmt.Errorf("failed to lock schema for migration: %w", err)
+			}
+		}
+
+		var applied int
+		err := tx.QueryRow(s.rebind("SELECT COUNT(*) FROM schema_version WHERE version = ?"), version).Scan(&applied)
+		if err != nil {
+			return fmt.Errorf("failed to check schema version: %w", err)
+		}
+		if (applied > 0) == up {
+			return nil
+		}
+
+		for _, stmt := range statements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+
+		if err := record(tx); err != nil {
+			return fmt.Errorf("failed to record schema version: %w", err)
+		}
+		return nil
+	})
+}
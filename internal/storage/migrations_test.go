@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"jobradar/internal/model"
+)
+
+func TestMigrate_UpgradesUnversionedDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+
+	// Create a database the way releases before schema versioning did
+	legacy, err := OpenSQLite(dbPath)
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	for _, stmt := range sqliteMigrations[0].Up {
+		if _, err := legacy.db.Exec(stmt); err != nil {
+			t.Fatalf("failed to create legacy schema: %v", err)
+		}
+	}
+	if err := legacy.MarkSeen("~01legacy", "Legacy Job", "https://example.com"); err != nil {
+		t.Fatalf("MarkSeen() error = %v", err)
+	}
+	legacy.Close()
+
+	s, err := NewSQLite(dbPath)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	version, err := s.SchemaVersion()
+	if err != nil {
+		t.Fatalf("SchemaVersion() error = %v", err)
+	}
+	if version != s.LatestVersion() {
+		t.Errorf("SchemaVersion() = %d, want %d", version, s.LatestVersion())
+	}
+
+	seen, err := s.IsSeen("~01legacy")
+	if err != nil {
+		t.Fatalf("IsSeen() error = %v", err)
+	}
+	if !seen {
+		t.Error("legacy data was lost during migration")
+	}
+}
+
+func TestMigrateTo_DownAndUp(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	if err := s.MigrateTo(1); err != nil {
+		t.Fatalf("MigrateTo(1) error = %v", err)
+	}
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'jobs'").Scan(&count)
+	if err != nil {
+		t.Fatalf("failed to inspect schema: %v", err)
+	}
+	if count != 0 {
+		t.Error("jobs table still exists after rolling back to version 1")
+	}
+
+	statuses, err := s.MigrationStatus()
+	if err != nil {
+		t.Fatalf("MigrationStatus() error = %v", err)
+	}
+	for _, st := range statuses {
+		applied := st.AppliedAt != nil
+		if applied != (st.Version <= 1) {
+			t.Errorf("migration %d applied = %v after rollback to 1", st.Version, applied)
+		}
+	}
+
+	if err := s.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if _, err := s.QueryJobs(model.JobQuery{}); err != nil {
+		t.Errorf("QueryJobs() after re-migrating error = %v", err)
+	}
+}
+
+func TestMigrateTo_InvalidVersion(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	if err := s.MigrateTo(s.LatestVersion() + 1); err == nil {
+		t.Error("MigrateTo() beyond latest version should fail")
+	}
+}
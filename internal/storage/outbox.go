@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+// notifyColumns lists the notify_records columns read by scanNotifyRecord
+const notifyColumns = `id, job_id, job_title, job_url, search_name, matched_keywords,
+	notify_channel, status, error_message, attempts, created_at, sent_at,
+	next_attempt_at, payload`
+
+// scanNotifyRecord scans a row selected with notifyColumns
+func scanNotifyRecord(rows *sql.Rows) (*model.NotifyRecord, error) {
+	r := &model.NotifyRecord{}
+	var sentAt, nextAttemptAt sql.NullTime
+	var payload sql.NullString
+	err := rows.Scan(
+		&r.ID, &r.JobID, &r.JobTitle, &r.JobURL, &r.SearchName,
+		&r.MatchedKeywords, &r.NotifyChannel, &r.Status,
+		&r.ErrorMessage, &r.Attempts, &r.CreatedAt, &sentAt,
+		&nextAttemptAt, &payload,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan notify record: %w", err)
+	}
+	if sentAt.Valid {
+		r.SentAt = &sentAt.Time
+	}
+	if nextAttemptAt.Valid {
+		r.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if payload.Valid && payload.String != "" {
+		r.Job = &model.MatchedJob{}
+		if err := json.Unmarshal([]byte(payload.String), r.Job); err != nil {
+			return nil, fmt.Errorf("failed to decode notification %d: %w", r.ID, err)
+		}
+	}
+	return r, nil
+}
+
+// encodePayload encodes the job stored with a pending notification
+func encodePayload(job *model.MatchedJob) (interface{}, error) {
+	if job == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return string(data), nil
+}
+
+// DueNotifications retrieves pending notifications whose next attempt is
+// due at now, oldest first
+func (s *sqlStore) DueNotifications(now time.Time, limit int) ([]*model.NotifyRecord, error) {
+	query := "SELECT " + notifyColumns + ` FROM notify_records
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id`
+	args := []interface{}{model.NotifyStatusPending, now}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(s.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*model.NotifyRecord
+	for rows.Next() {
+		r, err := scanNotifyRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// ClaimNotification pushes a due notification's next attempt back to
+// until, so other processes sharing the database skip it while it is being
+// sent. It reports false if the notification is no longer due.
+func (s *sqlStore) ClaimNotification(id int64, now, until time.Time) (bool, error) {
+	result, err := s.exec(s.rebind(`
+		UPDATE notify_records SET next_attempt_at = ?
+		WHERE id = ? AND status = ? AND next_attempt_at <= ?
+	`), until, id, model.NotifyStatusPending, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim notification: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim notification: %w", err)
+	}
+	return n == 1, nil
+}
+
+// UpdateNotifyRecord saves the outcome of a delivery attempt. The stored
+// job is dropped once the record is no longer pending.
+func (s *sqlStore) UpdateNotifyRecord(record *model.NotifyRecord) error {
+	query := `
+		UPDATE notify_records
+		SET status = ?, error_message = ?, attempts = ?, sent_at = ?, next_attempt_at = ?`
+	if record.Status != model.NotifyStatusPending {
+		query += ", payload = NULL"
+	}
+	query += " WHERE id = ?"
+
+	_, err := s.exec(s.rebind(query), record.Status, record.ErrorMessage, record.Attempts,
+		record.SentAt, record.NextAttemptAt, record.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update notify record: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+func TestSQLiteStore_Outbox(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	now := time.Now()
+	later := now.Add(time.Hour)
+	job := &model.MatchedJob{
+		Job:        &model.Job{ID: "~01a", Title: "Go API", URL: "https://example.com/a"},
+		SearchName: "Golang",
+		Matches:    []model.SearchMatch{{SearchName: "Golang", MatchScore: 1}},
+	}
+
+	records := []*model.NotifyRecord{
+		{JobID: "~01a", NotifyChannel: "telegram", Status: model.NotifyStatusPending, CreatedAt: now, NextAttemptAt: &now, Job: job},
+		{JobID: "~01a", NotifyChannel: "email", Status: model.NotifyStatusPending, CreatedAt: now, NextAttemptAt: &later, Job: job},
+		{JobID: "~01b", NotifyChannel: "email", Status: model.NotifyStatusSent, Attempts: 1, CreatedAt: now, SentAt: &now},
+	}
+	for _, r := range records {
+		if err := s.SaveNotifyRecord(r); err != nil {
+			t.Fatalf("SaveNotifyRecord() error = %v", err)
+		}
+		if r.ID == 0 {
+			t.Fatal("SaveNotifyRecord() did not set the ID")
+		}
+	}
+
+	due, err := s.DueNotifications(now, 0)
+	if err != nil {
+		t.Fatalf("DueNotifications() error = %v", err)
+	}
+	if len(due) != 1 || due[0].NotifyChannel != "telegram" {
+		t.Fatalf("DueNotifications() = %+v, want the telegram record", due)
+	}
+	if due[0].Job == nil || due[0].Job.Job.Title != "Go API" || due[0].Job.SearchName != "Golang" {
+		t.Errorf("stored job = %+v, want it decoded", due[0].Job)
+	}
+
+	claimed, err := s.ClaimNotification(due[0].ID, now, now.Add(10*time.Minute))
+	if err != nil || !claimed {
+		t.Fatalf("ClaimNotification() = %v, %v, want claimed", claimed, err)
+	}
+	if claimed, _ := s.ClaimNotification(due[0].ID, now, now.Add(10*time.Minute)); claimed {
+		t.Error("a claimed notification should not be claimed twice")
+	}
+
+	// Fail once and schedule a retry
+	r := due[0]
+	r.Attempts = 1
+	r.ErrorMessage = "timeout"
+	r.NextAttemptAt = &later
+	if err := s.UpdateNotifyRecord(r); err != nil {
+		t.Fatalf("UpdateNotifyRecord() error = %v", err)
+	}
+
+	stuck, err := s.QueryNotifyRecords(model.NotifyQuery{Stuck: true})
+	if err != nil {
+		t.Fatalf("QueryNotifyRecords() error = %v", err)
+	}
+	if len(stuck) != 1 || stuck[0].ErrorMessage != "timeout" || stuck[0].Attempts != 1 {
+		t.Errorf("stuck records = %+v, want the retried telegram record", stuck)
+	}
+
+	due, _ = s.DueNotifications(later, 0)
+	if len(due) != 2 {
+		t.Errorf("DueNotifications(later) returned %d records, want 2", len(due))
+	}
+
+	// Giving up drops the stored job
+	r.Status = model.NotifyStatusFailed
+	r.Attempts = 2
+	r.NextAttemptAt = nil
+	if err := s.UpdateNotifyRecord(r); err != nil {
+		t.Fatalf("UpdateNotifyRecord() error = %v", err)
+	}
+	failed, _ := s.QueryNotifyRecords(model.NotifyQuery{Status: model.NotifyStatusFailed})
+	if len(failed) != 1 || failed[0].Job != nil || failed[0].NextAttemptAt != nil {
+		t.Errorf("failed records = %+v, want one without a stored job", failed)
+	}
+}
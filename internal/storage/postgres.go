@@ -0,0 +1,424 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"jobradar/internal/model"
+
+	_ "github.com/lib/pq"
+)
+
+// postgresMigrationLockKey identifies the advisory lock that daemons
+// sharing a database take while migrating it
+const postgresMigrationLockKey = 0x6a6f6272 // "jobr"
+
+// postgresDialect describes PostgreSQL. Unlike SQLite's immediate
+// transactions, concurrent transactions do not wait for each other, so a
+// migration takes an advisory lock that is released when it commits.
+var postgresDialect = dialect{
+	numbered: true,
+	versionTable: `CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(200) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL
+	)`,
+	migrations:    postgresMigrations,
+	migrationLock: fmt.Sprintf("SELECT pg_advisory_xact_lock(%d)", postgresMigrationLockKey),
+}
+
+// postgresMigrations mirrors sqliteMigrations version for version
+var postgresMigrations = []migration{
+	{
+		Version: 1,
+		Name:    "initial schema",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS jobs_seen (
+				job_id VARCHAR(100) PRIMARY KEY,
+				job_title TEXT,
+				job_url TEXT,
+				first_seen_at TIMESTAMPTZ NOT NULL,
+				notified BOOLEAN DEFAULT FALSE,
+				created_at TIMESTAMPTZ DEFAULT NOW()
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_jobs_seen_created ON jobs_seen(created_at)`,
+
+			`CREATE TABLE IF NOT EXISTS notify_records (
+				id BIGSERIAL PRIMARY KEY,
+				job_id VARCHAR(100) NOT NULL,
+				job_title TEXT,
+				job_url TEXT,
+				search_name TEXT,
+				matched_keywords TEXT,
+				notify_channel VARCHAR(50) NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				error_message TEXT,
+				created_at TIMESTAMPTZ DEFAULT NOW(),
+				sent_at TIMESTAMPTZ
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_notify_records_job ON notify_records(job_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_notify_records_created ON notify_records(created_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_notify_records_status ON notify_records(status)`,
+
+			`CREATE TABLE IF NOT EXISTS run_logs (
+				id BIGSERIAL PRIMARY KEY,
+				started_at TIMESTAMPTZ NOT NULL,
+				finished_at TIMESTAMPTZ,
+				jobs_fetched INT DEFAULT 0,
+				jobs_matched INT DEFAULT 0,
+				jobs_notified INT DEFAULT 0,
+				jobs_skipped INT DEFAULT 0,
+				error_message TEXT,
+				created_at TIMESTAMPTZ DEFAULT NOW()
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_run_logs_started ON run_logs(started_at)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS run_logs`,
+			`DROP TABLE IF EXISTS notify_records`,
+			`DROP TABLE IF EXISTS jobs_seen`,
+		},
+	},
+	{
+		Version: 2,
+		Name:    "search matches",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS search_matches (
+				id BIGSERIAL PRIMARY KEY,
+				job_id VARCHAR(100) NOT NULL,
+				search_name TEXT NOT NULL,
+				matched_keywords TEXT,
+				match_score DOUBLE PRECISION DEFAULT 0,
+				created_at TIMESTAMPTZ DEFAULT NOW()
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_search_matches_job ON search_matches(job_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_search_matches_search ON search_matches(search_name)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS search_matches`,
+		},
+	},
+	{
+		Version: 3,
+		Name:    "job records",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS jobs (
+				job_id VARCHAR(100) PRIMARY KEY,
+				title TEXT,
+				description TEXT,
+				url TEXT,
+				job_type VARCHAR(20),
+				budget_min DOUBLE PRECISION,
+				budget_max DOUBLE PRECISION,
+				hourly_rate_min DOUBLE PRECISION,
+				hourly_rate_max DOUBLE PRECISION,
+				proposals INT,
+				client_country VARCHAR(100),
+				client_rating DOUBLE PRECISION,
+				client_total_spent DOUBLE PRECISION,
+				client_total_hires INT,
+				language VARCHAR(10),
+				posted_at TIMESTAMPTZ,
+				fetched_at TIMESTAMPTZ,
+				matched BOOLEAN DEFAULT FALSE,
+				match_score DOUBLE PRECISION DEFAULT 0,
+				first_seen_at TIMESTAMPTZ NOT NULL,
+				last_seen_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_jobs_posted ON jobs(posted_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_jobs_last_seen ON jobs(last_seen_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_jobs_matched ON jobs(matched)`,
+
+			`CREATE TABLE IF NOT EXISTS job_skills (
+				job_id VARCHAR(100) NOT NULL,
+				skill VARCHAR(200) NOT NULL,
+				PRIMARY KEY (job_id, skill)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_skills_skill ON job_skills(LOWER(skill))`,
+
+			`CREATE TABLE IF NOT EXISTS job_searches (
+				job_id VARCHAR(100) NOT NULL,
+				search_name TEXT NOT NULL,
+				matched BOOLEAN DEFAULT FALSE,
+				matched_keywords TEXT,
+				match_score DOUBLE PRECISION DEFAULT 0,
+				PRIMARY KEY (job_id, search_name)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_searches_search ON job_searches(search_name)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS job_searches`,
+			`DROP TABLE IF EXISTS job_skills`,
+			`DROP TABLE IF EXISTS jobs`,
+		},
+	},
+	{
+		Version: 4,
+		Name:    "run log daily aggregates",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS run_log_daily (
+				day VARCHAR(10) PRIMARY KEY,
+				runs INT NOT NULL DEFAULT 0,
+				failed_runs INT NOT NULL DEFAULT 0,
+				jobs_fetched INT NOT NULL DEFAULT 0,
+				jobs_matched INT NOT NULL DEFAULT 0,
+				jobs_notified INT NOT NULL DEFAULT 0,
+				jobs_skipped INT NOT NULL DEFAULT 0,
+				duration_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+				first_started_at TIMESTAMPTZ NOT NULL,
+				last_started_at TIMESTAMPTZ NOT NULL
+			)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS run_log_daily`,
+		},
+	},
+	{
+		Version: 5,
+		Name:    "job tracking",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS job_tracking (
+				job_id VARCHAR(100) PRIMARY KEY,
+				job_title TEXT,
+				job_url TEXT,
+				search_names TEXT,
+				keywords TEXT,
+				status VARCHAR(20) NOT NULL,
+				bid DOUBLE PRECISION,
+				connects INT NOT NULL DEFAULT 0,
+				created_at TIMESTAMPTZ NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL,
+				applied_at TIMESTAMPTZ,
+				closed_at TIMESTAMPTZ
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_tracking_status ON job_tracking(status)`,
+
+			`CREATE TABLE IF NOT EXISTS job_status_changes (
+				id BIGSERIAL PRIMARY KEY,
+				job_id VARCHAR(100) NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				changed_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_status_changes_job ON job_status_changes(job_id)`,
+
+			`CREATE TABLE IF NOT EXISTS job_notes (
+				id BIGSERIAL PRIMARY KEY,
+				job_id VARCHAR(100) NOT NULL,
+				body TEXT NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_job_notes_job ON job_notes(job_id)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS job_notes`,
+			`DROP TABLE IF EXISTS job_status_changes`,
+			`DROP TABLE IF EXISTS job_tracking`,
+		},
+	},
+	{
+		Version: 6,
+		Name:    "run source results",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS run_source_results (
+				id BIGSERIAL PRIMARY KEY,
+				run_id BIGINT NOT NULL,
+				source VARCHAR(20) NOT NULL,
+				search_name VARCHAR(100),
+				keyword VARCHAR(200),
+				started_at TIMESTAMPTZ NOT NULL,
+				duration_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+				fetched INT NOT NULL DEFAULT 0,
+				http_status INT,
+				error_message TEXT
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_run_source_results_run ON run_source_results(run_id)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS run_source_results`,
+		},
+	},
+	{
+		Version: 7,
+		Name:    "notification outbox",
+		Up: []string{
+			`ALTER TABLE notify_records ADD COLUMN attempts INT NOT NULL DEFAULT 0`,
+			`ALTER TABLE notify_records ADD COLUMN next_attempt_at TIMESTAMPTZ`,
+			`ALTER TABLE notify_records ADD COLUMN payload TEXT`,
+			`UPDATE notify_records SET attempts = 1`,
+			`CREATE INDEX IF NOT EXISTS idx_notify_records_due ON notify_records(status, next_attempt_at)`,
+		},
+		Down: []string{
+			`DROP INDEX IF EXISTS idx_notify_records_due`,
+			`ALTER TABLE notify_records DROP COLUMN payload`,
+			`ALTER TABLE notify_records DROP COLUMN next_attempt_at`,
+			`ALTER TABLE notify_records DROP COLUMN attempts`,
+		},
+	},
+	{
+		Version: 8,
+		Name:    "job detail positions",
+		Up: []string{
+			`ALTER TABLE job_skills ADD COLUMN position INT NOT NULL DEFAULT 0`,
+			`ALTER TABLE job_searches ADD COLUMN position INT NOT NULL DEFAULT 0`,
+			// ctid is the closest thing to insertion order for existing rows
+			`UPDATE job_skills SET position = o.position
+			FROM (SELECT ctid AS rid, ROW_NUMBER() OVER (PARTITION BY job_id ORDER BY ctid) - 1 AS position FROM job_skills) o
+			WHERE job_skills.ctid = o.rid`,
+			`UPDATE job_searches SET position = o.position
+			FROM (SELECT ctid AS rid, ROW_NUMBER() OVER (PARTITION BY job_id ORDER BY ctid) - 1 AS position FROM job_searches) o
+			WHERE job_searches.ctid = o.rid`,
+		},
+		Down: []string{
+			`ALTER TABLE job_searches DROP COLUMN position`,
+			`ALTER TABLE job_skills DROP COLUMN position`,
+		},
+	},
+}
+
+// PostgresStore handles PostgreSQL database operations, letting several
+// daemons share one database
+type PostgresStore struct {
+	*sqlStore
+}
+
+// NewPostgres connects to PostgreSQL and applies any pending schema migrations
+func NewPostgres(dsn string) (*PostgresStore, error) {
+	s, err := OpenPostgres(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Migrate(); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return s, nil
+}
+
+// PostgreSQL connection pool policy. Connections are recycled periodically
+// so the pool recovers from server restarts and failovers.
+const (
+	postgresMaxOpenConns    = 10
+	postgresMaxIdleConns    = 5
+	postgresConnMaxLifetime = 30 * time.Minute
+)
+
+// OpenPostgres connects to PostgreSQL without applying migrations
+func OpenPostgres(dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(postgresMaxOpenConns)
+	db.SetMaxIdleConns(postgresMaxIdleConns)
+	db.SetConnMaxLifetime(postgresConnMaxLifetime)
+
+	// Test connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresStore{sqlStore: &sqlStore{db: db, dialect: postgresDialect}}, nil
+}
+
+// jobDocument is the weighted text search document of a jobs row j
+const jobDocument = `setweight(to_tsvector('english', COALESCE(j.title, '')), 'A') ||
+	setweight(to_tsvector('english', COALESCE((SELECT string_agg(skill, ' ') FROM job_skills s WHERE s.job_id = j.job_id), '')), 'B') ||
+	setweight(to_tsvector('english', COALESCE(j.description, '')), 'D')`
+
+// FullTextSearch reports whether searches are ranked; always true for PostgreSQL
+func (s *PostgresStore) FullTextSearch() bool {
+	return true
+}
+
+// SearchJobs finds stored jobs matching a full-text query, best match first.
+// Documents are built on the fly, which is fine for the few thousand jobs a
+// typical database holds.
+func (s *PostgresStore) SearchJobs(q model.JobSearch) ([]*model.JobSearchResult, error) {
+	query := websearchQuery(q.Query)
+	if query == "" {
+		return nil, fmt.Errorf("empty search query")
+	}
+
+	options := fmt.Sprintf("MaxWords=%d, MinWords=%d", snippetWords, snippetWords/2)
+	if q.HighlightStart != "" || q.HighlightEnd != "" {
+		options += fmt.Sprintf(`, StartSel="%s", StopSel="%s"`, q.HighlightStart, q.HighlightEnd)
+	}
+
+	conditions, args := searchConditions(q)
+	args = append([]interface{}{options, query}, args...)
+
+	sqlQuery := `
+		SELECT ` + qualifyColumns(jobColumns, "j") + `,
+			ts_rank(d.doc, t.query) AS rank,
+			ts_headline('english', COALESCE(j.description, ''), t.query, ?)
+		FROM jobs j
+		CROSS JOIN LATERAL (SELECT ` + jobDocument + ` AS doc) d
+		CROSS JOIN websearch_to_tsquery('english', ?) AS t(query)
+		WHERE d.doc @@ t.query`
+	for _, c := range conditions {
+		sqlQuery += " AND " + c
+	}
+	sqlQuery += " ORDER BY rank DESC"
+	if q.Limit > 0 {
+		sqlQuery += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	return s.scanSearchResults(sqlQuery, args...)
+}
+
+// websearchQuery converts a user query into websearch_to_tsquery syntax,
+// which has no prefix matching and spells NOT as a leading minus
+func websearchQuery(query string) string {
+	var parts []string
+	negate := false
+	for _, t := range parseSearchQuery(query) {
+		switch {
+		case t.operator && t.text == "NOT":
+			negate = true
+			continue
+		case t.operator && t.text == "OR":
+			parts = append(parts, "or")
+			continue
+		case t.operator:
+			continue
+		}
+
+		term := `"` + strings.ReplaceAll(t.text, `"`, "") + `"`
+		if negate {
+			term = "-" + term
+			negate = false
+		}
+		parts = append(parts, term)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Backup is not supported for PostgreSQL, which has its own tooling
+func (s *PostgresStore) Backup(dest string) error {
+	return fmt.Errorf("backup is not supported for PostgreSQL; use pg_dump")
+}
+
+// Restore is not supported for PostgreSQL, which has its own tooling
+func (s *PostgresStore) Restore(src string) error {
+	return fmt.Errorf("restore is not supported for PostgreSQL; use pg_restore")
+}
+
+// Vacuum reclaims space and refreshes planner statistics
+func (s *PostgresStore) Vacuum() error {
+	if _, err := s.db.Exec("VACUUM ANALYZE"); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	return nil
+}
+
+// Check verifies the schema version; the server checks its own storage
+func (s *PostgresStore) Check() ([]string, error) {
+	return s.checkSchema()
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+func TestRebind(t *testing.T) {
+	s := &sqlStore{dialect: postgresDialect}
+
+	got := s.rebind("SELECT * FROM jobs WHERE job_id = ? AND last_seen_at >= ? LIMIT ?")
+	want := "SELECT * FROM jobs WHERE job_id = $1 AND last_seen_at >= $2 LIMIT $3"
+	if got != want {
+		t.Errorf("rebind() = %q, want %q", got, want)
+	}
+
+	s = &sqlStore{dialect: sqliteDialect}
+	query := "SELECT * FROM jobs WHERE job_id = ?"
+	if got := s.rebind(query); got != query {
+		t.Errorf("rebind() = %q, want unchanged", got)
+	}
+}
+
+// TestPostgresStore runs against a real server when
+// JOBRADAR_TEST_POSTGRES_DSN points at a disposable database
+func TestPostgresStore(t *testing.T) {
+	dsn := os.Getenv("JOBRADAR_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("JOBRADAR_TEST_POSTGRES_DSN not set")
+	}
+
+	s, err := NewPostgres(dsn)
+	if err != nil {
+		t.Fatalf("NewPostgres() error = %v", err)
+	}
+	defer s.Close()
+
+	jobID := "~01pgtest" + time.Now().Format("150405.000000")
+
+	if err := s.MarkSeen(jobID, "Postgres Job", "https://example.com"); err != nil {
+		t.Fatalf("MarkSeen() error = %v", err)
+	}
+	seen, err := s.IsSeen(jobID)
+	if err != nil || !seen {
+		t.Fatalf("IsSeen() = %v, %v, want true", seen, err)
+	}
+
+	budget := 500.0
+	record := &model.JobRecord{
+		Job: &model.Job{
+			ID:        jobID,
+			Title:     "Postgres Job",
+			JobType:   model.JobTypeFixed,
+			BudgetMax: &budget,
+			Skills:    []string{"Go", "PostgreSQL"},
+			PostedAt:  time.Now(),
+			FetchedAt: time.Now(),
+		},
+		Searches:   []string{"Golang API"},
+		Matches:    []model.SearchMatch{{SearchName: "Golang API", MatchedKeywords: []string{"go"}, MatchScore: 1}},
+		MatchScore: 1,
+	}
+	if err := s.SaveJobs([]*model.JobRecord{record}); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+
+	got, err := s.GetJob(jobID)
+	if err != nil || got == nil {
+		t.Fatalf("GetJob() = %v, %v", got, err)
+	}
+	if len(got.Job.Skills) != 2 || !got.Matched() {
+		t.Errorf("GetJob() = %+v, want 2 skills and a match", got)
+	}
+
+	stats := model.NewRunStats()
+	stats.Finish()
+	if err := s.SaveRunLog(stats); err != nil {
+		t.Fatalf("SaveRunLog() error = %v", err)
+	}
+	overall, err := s.GetOverallStats()
+	if err != nil || overall.LastRunAt == nil {
+		t.Errorf("GetOverallStats() = %+v, %v, want last run set", overall, err)
+	}
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+)
+
+// dayFormat is the key of run_log_daily rows; days are in UTC
+const dayFormat = "2006-01-02"
+
+// Cleanup removes records older than each table's retention period. Run
+// logs are rolled up into daily totals first, so overall stats keep
+// counting them after they are deleted.
+func (s *sqlStore) Cleanup(retention config.RetentionConfig) error {
+	now := time.Now()
+	cutoff := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+
+	if retention.JobsSeen > 0 {
+		if _, err := s.exec(s.rebind("DELETE FROM jobs_seen WHERE created_at < ?"), cutoff(retention.JobsSeen)); err != nil {
+			return fmt.Errorf("failed to cleanup jobs_seen: %w", err)
+		}
+	}
+
+	// Pending records are the delivery queue: retries and held digests are
+	// left to the outbox, which expires them, however old they are
+	if retention.Notifications > 0 {
+		query := s.rebind("DELETE FROM notify_records WHERE created_at < ? AND status <> ?")
+		if _, err := s.exec(query, cutoff(retention.Notifications), model.NotifyStatusPending); err != nil {
+			return fmt.Errorf("failed to cleanup notify_records: %w", err)
+		}
+	}
+
+	if retention.RunLogs > 0 {
+		if err := s.rollupRunLogs(cutoff(retention.RunLogs)); err != nil {
+			return err
+		}
+	}
+
+	if retention.SearchMatches > 0 {
+		if _, err := s.exec(s.rebind("DELETE FROM search_matches WHERE created_at < ?"), cutoff(retention.SearchMatches)); err != nil {
+			return fmt.Errorf("failed to cleanup search_matches: %w", err)
+		}
+	}
+
+	if retention.Jobs > 0 {
+		if err := s.cleanupJobs(cutoff(retention.Jobs)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dailyRuns accumulates the run logs of one day
+type dailyRuns struct {
+	runs, failed                        int
+	fetched, matched, notified, skipped int
+	duration                            float64
+	firstStartedAt, lastStartedAt       time.Time
+}
+
+// rollupRunLogs adds run logs started before the cutoff to run_log_daily
+// and deletes them, in one transaction so no run is counted twice
+func (s *sqlStore) rollupRunLogs(cutoff time.Time) error {
+	return s.transact(func(tx *sql.Tx) error {
+		return s.rollupRunLogsTx(tx, cutoff)
+	})
+}
+
+// rollupRunLogsTx does the work of rollupRunLogs inside tx
+func (s *sqlStore) rollupRunLogsTx(tx *sql.Tx, cutoff time.Time) error {
+	rows, err := tx.Query(s.rebind(`
+		SELECT started_at, finished_at, jobs_fetched, jobs_matched,
+		       jobs_notified, jobs_skipped, COALESCE(error_message, '')
+		FROM run_logs
+		WHERE started_at < ?
+	`), cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to read run logs: %w", err)
+	}
+
+	days := make(map[string]*dailyRuns)
+	var order []string
+	for rows.Next() {
+		var startedAt time.Time
+		var finishedAt sql.NullTime
+		var fetched, matched, notified, skipped int
+		var errMsg string
+		if err := rows.Scan(&startedAt, &finishedAt, &fetched, &matched, &notified, &skipped, &errMsg); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan run log: %w", err)
+		}
+
+		key := startedAt.UTC().Format(dayFormat)
+		d, ok := days[key]
+		if !ok {
+			d = &dailyRuns{firstStartedAt: startedAt, lastStartedAt: startedAt}
+			days[key] = d
+			order = append(order, key)
+		}
+
+		d.runs++
+		if errMsg != "" {
+			d.failed++
+		}
+		d.fetched += fetched
+		d.matched += matched
+		d.notified += notified
+		d.skipped += skipped
+		if finishedAt.Valid {
+			d.duration += finishedAt.Time.Sub(startedAt).Seconds()
+		}
+		if startedAt.Before(d.firstStartedAt) {
+			d.firstStartedAt = startedAt
+		}
+		if startedAt.After(d.lastStartedAt) {
+			d.lastStartedAt = startedAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("failed to read run logs: %w", err)
+	}
+	rows.Close()
+
+	for _, key := range order {
+		d := days[key]
+		_, err := tx.Exec(s.rebind(`
+			INSERT INTO run_log_daily
+			(day, runs, failed_runs, jobs_fetched, jobs_matched, jobs_notified,
+			 jobs_skipped, duration_seconds, first_started_at, last_started_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(day) DO UPDATE SET
+				runs = run_log_daily.runs + excluded.runs,
+				failed_runs = run_log_daily.failed_runs + excluded.failed_runs,
+				jobs_fetched = run_log_daily.jobs_fetched + excluded.jobs_fetched,
+				jobs_matched = run_log_daily.jobs_matched + excluded.jobs_matched,
+				jobs_notified = run_log_daily.jobs_notified + excluded.jobs_notified,
+				jobs_skipped = run_log_daily.jobs_skipped + excluded.jobs_skipped,
+				duration_seconds = run_log_daily.duration_seconds + excluded.duration_seconds,
+				first_started_at = CASE WHEN excluded.first_started_at < run_log_daily.first_started_at
+					THEN excluded.first_started_at ELSE run_log_daily.first_started_at END,
+				last_started_at = CASE WHEN excluded.last_started_at > run_log_daily.last_started_at
+					THEN excluded.last_started_at ELSE run_log_daily.last_started_at END
+		`), key, d.runs, d.failed, d.fetched, d.matched, d.notified,
+			d.skipped, d.duration, d.firstStartedAt, d.lastStartedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save daily run totals: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(s.rebind("DELETE FROM run_logs WHERE started_at < ?"), cutoff); err != nil {
+		return fmt.Errorf("failed to cleanup run_logs: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM run_source_results WHERE run_id NOT IN (SELECT id FROM run_logs)"); err != nil {
+		return fmt.Errorf("failed to cleanup run_source_results: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+)
+
+func TestSQLiteStore_CleanupPerTable(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	old := time.Now().AddDate(0, 0, -10)
+	if err := s.MarkSeen("~01old", "Old Job", "https://example.com"); err != nil {
+		t.Fatalf("MarkSeen() error = %v", err)
+	}
+	if _, err := s.db.Exec("UPDATE jobs_seen SET created_at = ?", old); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SaveNotifyRecord(&model.NotifyRecord{
+		JobID: "~01old", NotifyChannel: "telegram", Status: model.NotifyStatusSent, CreatedAt: old,
+	}); err != nil {
+		t.Fatalf("SaveNotifyRecord() error = %v", err)
+	}
+
+	err = s.Cleanup(config.RetentionConfig{JobsSeen: 30, Notifications: 7})
+	if err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	if seen, _ := s.IsSeen("~01old"); !seen {
+		t.Error("jobs_seen row within its 30 day retention was deleted")
+	}
+	records, _ := s.GetNotifyRecords(10)
+	if len(records) != 0 {
+		t.Errorf("notify_records older than 7 days kept: %d", len(records))
+	}
+}
+
+func TestSQLiteStore_CleanupKeepsPendingNotifications(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	old := time.Now().AddDate(0, 0, -10)
+	for _, status := range []model.NotifyStatus{model.NotifyStatusPending, model.NotifyStatusFailed} {
+		if err := s.SaveNotifyRecord(&model.NotifyRecord{
+			JobID: "~01" + string(status), NotifyChannel: "telegram", Status: status, CreatedAt: old, NextAttemptAt: &old,
+		}); err != nil {
+			t.Fatalf("SaveNotifyRecord() error = %v", err)
+		}
+	}
+
+	if err := s.Cleanup(config.RetentionConfig{Notifications: 7}); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	records, _ := s.GetNotifyRecords(10)
+	if len(records) != 1 || records[0].Status != model.NotifyStatusPending {
+		t.Fatalf("records after cleanup = %+v, want only the pending one", records)
+	}
+	due, err := s.DueNotifications(time.Now(), 0)
+	if err != nil || len(due) != 1 {
+		t.Errorf("DueNotifications() = %d records (%v), want the old pending one still queued", len(due), err)
+	}
+}
+
+func TestSQLiteStore_CleanupRollsUpRunLogs(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	// Daily totals are keyed by UTC day; start at noon so the first two
+	// runs always share a day
+	now := time.Now()
+	y, m, d := now.UTC().AddDate(0, 0, -20).Date()
+	noon := time.Date(y, m, d, 12, 0, 0, 0, time.UTC)
+	runs := []time.Time{
+		noon,
+		noon.Add(time.Minute),
+		noon.AddDate(0, 0, 5),
+		now,
+	}
+	for _, started := range runs {
+		finished := started.Add(2 * time.Second)
+		stats := &model.RunStats{
+			StartedAt:    started,
+			FinishedAt:   &finished,
+			JobsFetched:  10,
+			JobsMatched:  3,
+			JobsNotified: 2,
+		}
+		if err := s.SaveRunLog(stats); err != nil {
+			t.Fatalf("SaveRunLog() error = %v", err)
+		}
+	}
+
+	before, err := s.GetOverallStats()
+	if err != nil {
+		t.Fatalf("GetOverallStats() error = %v", err)
+	}
+
+	// Run twice to check rolled-up runs are not counted again
+	for i := 0; i < 2; i++ {
+		if err := s.Cleanup(config.RetentionConfig{RunLogs: 7}); err != nil {
+			t.Fatalf("Cleanup() error = %v", err)
+		}
+	}
+
+	logs, err := s.QueryRunLogs(model.RunLogQuery{})
+	if err != nil {
+		t.Fatalf("QueryRunLogs() error = %v", err)
+	}
+	if len(logs) != 1 {
+		t.Errorf("run_logs has %d rows after cleanup, want 1", len(logs))
+	}
+
+	var days int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM run_log_daily").Scan(&days); err != nil {
+		t.Fatal(err)
+	}
+	if days != 2 {
+		t.Errorf("run_log_daily has %d rows, want 2", days)
+	}
+
+	after, err := s.GetOverallStats()
+	if err != nil {
+		t.Fatalf("GetOverallStats() error = %v", err)
+	}
+	if after.TotalRuns != 4 || after.TotalJobsFetched != before.TotalJobsFetched ||
+		after.TotalJobsMatched != before.TotalJobsMatched || after.TotalJobsNotified != before.TotalJobsNotified {
+		t.Errorf("totals changed after rollup: before %+v, after %+v", before, after)
+	}
+	if after.TrackingSince == nil || after.TrackingSince.Sub(runs[0]).Abs() > time.Second {
+		t.Errorf("TrackingSince = %v, want %v", after.TrackingSince, runs[0])
+	}
+	if after.LastRunAt == nil || after.LastRunAt.Sub(runs[3]).Abs() > time.Second {
+		t.Errorf("LastRunAt = %v, want %v", after.LastRunAt, runs[3])
+	}
+}
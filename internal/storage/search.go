@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"jobradar/internal/model"
+)
+
+// snippetWords is the approximate length of search snippets in words
+const snippetWords = 16
+
+// budgetExpr picks the budget a job is filtered on, the same way the
+// filter package does: fixed-price budgets or hourly rates by job type
+const budgetExpr = `CASE WHEN j.job_type = 'hourly'
+	THEN COALESCE(j.hourly_rate_max, j.hourly_rate_min)
+	ELSE COALESCE(j.budget_max, j.budget_min) END`
+
+// searchConditions builds the date and budget conditions of a search
+func searchConditions(q model.JobSearch) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if !q.Since.IsZero() {
+		conditions = append(conditions, "j.posted_at >= ?")
+		args = append(args, q.Since)
+	}
+	if !q.Until.IsZero() {
+		conditions = append(conditions, "j.posted_at < ?")
+		args = append(args, q.Until)
+	}
+	if q.BudgetMin > 0 {
+		conditions = append(conditions, "("+budgetExpr+") >= ?")
+		args = append(args, q.BudgetMin)
+	}
+	if q.BudgetMax > 0 {
+		conditions = append(conditions, "("+budgetExpr+") <= ?")
+		args = append(args, q.BudgetMax)
+	}
+
+	return conditions, args
+}
+
+// qualifyColumns prefixes each column in a comma-separated list with a table alias
+func qualifyColumns(columns, alias string) string {
+	parts := strings.Split(columns, ",")
+	for i, p := range parts {
+		parts[i] = alias + "." + strings.TrimSpace(p)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// searchToken is a term or quoted phrase from a search query
+type searchToken struct {
+	text     string
+	prefix   bool // Ended with *
+	operator bool // OR, AND or NOT
+}
+
+// parseSearchQuery splits a query into terms, quoted phrases and operators
+func parseSearchQuery(query string) []searchToken {
+	var tokens []searchToken
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		if runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if text := strings.TrimSpace(string(runes[i+1 : end])); text != "" {
+				tokens = append(tokens, searchToken{text: text})
+			}
+			i = end + 1
+			continue
+		}
+
+		end := i
+		for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+			end++
+		}
+		word := string(runes[i:end])
+		i = end
+
+		switch word {
+		case "OR", "AND", "NOT":
+			tokens = append(tokens, searchToken{text: word, operator: true})
+			continue
+		}
+
+		prefix := strings.HasSuffix(word, "*")
+		word = strings.TrimRight(word, "*")
+		if word != "" {
+			tokens = append(tokens, searchToken{text: word, prefix: prefix})
+		}
+	}
+
+	// Operators are only meaningful between two terms
+	for len(tokens) > 0 && tokens[0].operator {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 0 && tokens[len(tokens)-1].operator {
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	return tokens
+}
+
+// searchTerms returns the plain terms of a query, without operators
+func searchTerms(query string) []string {
+	var terms []string
+	for _, t := range parseSearchQuery(query) {
+		if !t.operator {
+			terms = append(terms, t.text)
+		}
+	}
+	return terms
+}
+
+// ftsQuery converts a user query into FTS5 syntax. Every term is quoted so
+// punctuation such as "node.js" or "c++" cannot cause syntax errors.
+func ftsQuery(query string) string {
+	var parts []string
+	lastOperator := true
+	for _, t := range parseSearchQuery(query) {
+		if t.operator {
+			if lastOperator {
+				continue
+			}
+			parts = append(parts, t.text)
+			lastOperator = true
+			continue
+		}
+
+		term := `"` + strings.ReplaceAll(t.text, `"`, `""`) + `"`
+		if t.prefix {
+			term += "*"
+		}
+		parts = append(parts, term)
+		lastOperator = false
+	}
+	return strings.Join(parts, " ")
+}
+
+// scanSearchResults scans rows of jobColumns followed by rank and snippet
+func (s *sqlStore) scanSearchResults(query string, args ...interface{}) ([]*model.JobSearchResult, error) {
+	rows, err := s.db.Query(s.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search jobs: %w", err)
+	}
+
+	var results []*model.JobSearchResult
+	for rows.Next() {
+		result := &model.JobSearchResult{JobRecord: &model.JobRecord{Job: &model.Job{}}}
+		if err := scanJobWith(rows, result.JobRecord, &result.Rank, &result.Snippet); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("failed to search jobs: %w", err)
+	}
+	rows.Close()
+
+	for _, r := range results {
+		if err := s.loadJobDetails(r.JobRecord); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// likeSearch finds jobs containing every term in their title, description
+// or skills. It is used when the database has no full-text index; results
+// are unranked, ordered by posting time, and every term is required.
+func (s *sqlStore) likeSearch(q model.JobSearch) ([]*model.JobSearchResult, error) {
+	terms := searchTerms(q.Query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("empty search query")
+	}
+
+	conditions, args := searchConditions(q)
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		conditions = append(conditions, `(j.title LIKE ? ESCAPE '\' OR j.description LIKE ? ESCAPE '\'
+			OR j.job_id IN (SELECT job_id FROM job_skills WHERE skill LIKE ? ESCAPE '\'))`)
+		args = append(args, pattern, pattern, pattern)
+	}
+
+	query := "SELECT " + qualifyColumns(jobColumns, "j") + ", 0, '' FROM jobs j" +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY j.posted_at DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	results, err := s.scanSearchResults(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		// Prefer an excerpt of the description, like FTS5 snippets
+		var found bool
+		for _, text := range []string{r.Job.Description, r.Job.Title, strings.Join(r.Job.Skills, ", ")} {
+			if r.Snippet, found = makeSnippet(text, terms, q.HighlightStart, q.HighlightEnd); found {
+				break
+			}
+		}
+		if !found {
+			r.Snippet, _ = makeSnippet(r.Job.Description, terms, q.HighlightStart, q.HighlightEnd)
+		}
+	}
+	return results, nil
+}
+
+// escapeLike escapes LIKE wildcards so terms match literally
+func escapeLike(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s)
+}
+
+// makeSnippet cuts a window of words around the first term found in text
+// and wraps every word containing a term in the highlight markers. It
+// reports whether any term was found; if not, the snippet is the start of text.
+func makeSnippet(text string, terms []string, start, end string) (string, bool) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return "", false
+	}
+
+	lowerTerms := make([]string, len(terms))
+	for i, t := range terms {
+		lowerTerms[i] = strings.ToLower(t)
+	}
+	matches := func(word string) bool {
+		w := strings.ToLower(word)
+		for _, t := range lowerTerms {
+			if strings.Contains(w, t) {
+				return true
+			}
+		}
+		return false
+	}
+
+	first, found := 0, false
+	for i, w := range words {
+		if matches(w) {
+			first, found = i, true
+			break
+		}
+	}
+
+	from := first - snippetWords/4
+	if from < 0 {
+		from = 0
+	}
+	to := from + snippetWords
+	if to > len(words) {
+		to = len(words)
+	}
+
+	window := make([]string, 0, to-from)
+	for _, w := range words[from:to] {
+		if matches(w) {
+			w = start + w + end
+		}
+		window = append(window, w)
+	}
+
+	snippet := strings.Join(window, " ")
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(words) {
+		snippet += "…"
+	}
+	return snippet, found
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+func TestFtsQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"kubernetes migration", `"kubernetes" "migration"`},
+		{`"react native" OR flutter`, `"react native" OR "flutter"`},
+		{"node.js c++", `"node.js" "c++"`},
+		{"kube*", `"kube"*`},
+		{"OR golang OR", `"golang"`},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ftsQuery(tt.query); got != tt.want {
+			t.Errorf("ftsQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSQLiteStore_SearchJobs(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	now := time.Now()
+	small, large := 300.0, 5000.0
+	records := []*model.JobRecord{
+		{Job: &model.Job{
+			ID:          "~01k8s",
+			Title:       "Kubernetes migration for legacy services",
+			Description: "We are moving our services from VMs to a managed cluster and need help.",
+			JobType:     model.JobTypeFixed,
+			BudgetMax:   &large,
+			Skills:      []string{"Kubernetes", "Terraform"},
+			PostedAt:    now.Add(-2 * time.Hour),
+		}},
+		{Job: &model.Job{
+			ID:          "~01site",
+			Title:       "Landing page",
+			Description: "Simple marketing site, no Kubernetes needed.",
+			JobType:     model.JobTypeFixed,
+			BudgetMax:   &small,
+			Skills:      []string{"HTML"},
+			PostedAt:    now.Add(-10 * 24 * time.Hour),
+		}},
+	}
+	if err := s.SaveJobs(records); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+
+	results, err := s.SearchJobs(model.JobSearch{Query: "kubernetes", HighlightStart: "[", HighlightEnd: "]"})
+	if err != nil {
+		t.Fatalf("SearchJobs() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("SearchJobs() returned %d results, want 2", len(results))
+	}
+	if !strings.Contains(results[0].Snippet, "[") {
+		t.Errorf("snippet %q has no highlighted term", results[0].Snippet)
+	}
+
+	results, err = s.SearchJobs(model.JobSearch{Query: "terraform"})
+	if err != nil {
+		t.Fatalf("SearchJobs() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Job.ID != "~01k8s" {
+		t.Errorf("skill search returned %d results, want ~01k8s", len(results))
+	}
+
+	results, err = s.SearchJobs(model.JobSearch{Query: "kubernetes", Since: now.Add(-24 * time.Hour)})
+	if err != nil {
+		t.Fatalf("SearchJobs() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Job.ID != "~01k8s" {
+		t.Errorf("date filtered search returned %d results, want ~01k8s", len(results))
+	}
+
+	results, err = s.SearchJobs(model.JobSearch{Query: "kubernetes", BudgetMax: 1000})
+	if err != nil {
+		t.Fatalf("SearchJobs() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Job.ID != "~01site" {
+		t.Errorf("budget filtered search returned %d results, want ~01site", len(results))
+	}
+
+	// Updates and cleanup keep the index in step with the jobs table
+	records[0].Job.Title = "Cluster upgrade"
+	records[0].Job.Skills = []string{"Helm"}
+	if err := s.SaveJobs(records[:1]); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+	results, err = s.SearchJobs(model.JobSearch{Query: "helm"})
+	if err != nil {
+		t.Fatalf("SearchJobs() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("search after update returned %d results, want 1", len(results))
+	}
+
+	if _, err := s.SearchJobs(model.JobSearch{Query: "  "}); err == nil {
+		t.Error("SearchJobs() with an empty query should fail")
+	}
+}
@@ -2,250 +2,271 @@ package storage
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"jobradar/internal/model"
+)
+
+// ErrSQLiteCGO is returned by every SQLite operation in builds without cgo,
+// which go-sqlite3 needs. PostgreSQL works in such builds.
+var ErrSQLiteCGO = errors.New("sqlite requires cgo; rebuild with CGO_ENABLED=1 or set storage.dsn to use PostgreSQL")
+
+// sqliteTimestampFormats are the layouts go-sqlite3 uses for timestamps,
+// copied so that scanning them does not depend on the cgo driver
+var sqliteTimestampFormats = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// sqliteDialect describes SQLite
+var sqliteDialect = dialect{
+	versionTable: `CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(200) NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`,
+	migrations: sqliteMigrations,
+	isBusy:     isSQLiteBusy,
+}
+
+// sqliteParams are the connection settings applied to every SQLite
+// connection. WAL lets readers run alongside the single writer, the busy
+// timeout makes a blocked writer wait rather than fail at once, and
+// immediate transactions take the write lock up front so two transactions
+// cannot deadlock upgrading read locks.
+const sqliteParams = "_journal_mode=WAL&_busy_timeout=5000&_synchronous=NORMAL&_txlock=immediate"
 
-	_ "github.com/mattn/go-sqlite3"
+// sqliteDSN appends sqliteParams to a database path, which may be a
+// "file:" URI with a query string of its own
+func sqliteDSN(dbPath string) string {
+	if strings.Contains(dbPath, "?") {
+		return dbPath + "&" + sqliteParams
+	}
+	return dbPath + "?" + sqliteParams
+}
+
+// SQLite connection pool policy. SQLite allows one writer at a time, so a
+// large pool only adds connections waiting on the lock; a few are enough to
+// keep readers from queuing behind a write.
+const (
+	sqliteMaxOpenConns    = 4
+	sqliteMaxIdleConns    = 2
+	sqliteConnMaxIdleTime = 5 * time.Minute
 )
 
-// Storage handles SQLite database operations
-type Storage struct {
-	db *sql.DB
+// searchIndexSchema creates the FTS5 index over jobs and the triggers that
+// keep it in sync. Index rows share the rowid of their jobs row.
+var searchIndexSchema = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS jobs_fts USING fts5(
+		title, description, skills,
+		tokenize = 'porter unicode61'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS jobs_fts_insert AFTER INSERT ON jobs BEGIN
+		INSERT INTO jobs_fts (rowid, title, description, skills)
+		VALUES (new.rowid, new.title, new.description, '');
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS jobs_fts_update AFTER UPDATE OF title, description ON jobs BEGIN
+		UPDATE jobs_fts SET title = new.title, description = new.description
+		WHERE rowid = new.rowid;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS jobs_fts_delete AFTER DELETE ON jobs BEGIN
+		DELETE FROM jobs_fts WHERE rowid = old.rowid;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS job_skills_fts_insert AFTER INSERT ON job_skills BEGIN
+		UPDATE jobs_fts
+		SET skills = (SELECT group_concat(skill, ' ') FROM job_skills WHERE job_id = new.job_id)
+		WHERE rowid = (SELECT rowid FROM jobs WHERE job_id = new.job_id);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS job_skills_fts_delete AFTER DELETE ON job_skills BEGIN
+		UPDATE jobs_fts
+		SET skills = COALESCE((SELECT group_concat(skill, ' ') FROM job_skills WHERE job_id = old.job_id), '')
+		WHERE rowid = (SELECT rowid FROM jobs WHERE job_id = old.job_id);
+	END`,
 }
 
-// New creates a new Storage instance and initializes the database
-func New(dbPath string) (*Storage, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+// SQLiteStore handles SQLite database operations
+type SQLiteStore struct {
+	*sqlStore
+	fts bool // FTS5 search index is available
+}
+
+// NewSQLite opens a SQLite database and applies any pending schema migrations
+func NewSQLite(dbPath string) (*SQLiteStore, error) {
+	s, err := OpenSQLite(dbPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, err
 	}
 
-	// Test connection
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	if err := s.Migrate(); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
-	s := &Storage{db: db}
-	if err := s.migrate(); err != nil {
-		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	if err := s.ensureSearchIndex(); err != nil {
+		s.Close()
+		return nil, err
 	}
 
 	return s, nil
 }
 
-// migrate creates the necessary tables
-func (s *Storage) migrate() error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS jobs_seen (
-			job_id VARCHAR(100) PRIMARY KEY,
-			job_title VARCHAR(500),
-			job_url VARCHAR(1000),
-			first_seen_at TIMESTAMP NOT NULL,
-			notified BOOLEAN DEFAULT FALSE,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_jobs_seen_created ON jobs_seen(created_at)`,
-
-		`CREATE TABLE IF NOT EXISTS notify_records (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			job_id VARCHAR(100) NOT NULL,
-			job_title VARCHAR(500),
-			job_url VARCHAR(1000),
-			search_name VARCHAR(100),
-			matched_keywords TEXT,
-			notify_channel VARCHAR(50) NOT NULL,
-			status VARCHAR(20) NOT NULL,
-			error_message TEXT,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			sent_at TIMESTAMP
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_notify_records_job ON notify_records(job_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_notify_records_created ON notify_records(created_at)`,
-		`CREATE INDEX IF NOT EXISTS idx_notify_records_status ON notify_records(status)`,
-
-		`CREATE TABLE IF NOT EXISTS run_logs (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			started_at TIMESTAMP NOT NULL,
-			finished_at TIMESTAMP,
-			jobs_fetched INT DEFAULT 0,
-			jobs_matched INT DEFAULT 0,
-			jobs_notified INT DEFAULT 0,
-			jobs_skipped INT DEFAULT 0,
-			error_message TEXT,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_run_logs_started ON run_logs(started_at)`,
-	}
-
-	for _, query := range queries {
-		if _, err := s.db.Exec(query); err != nil {
-			return fmt.Errorf("failed to execute migration: %w", err)
-		}
+// OpenSQLite opens a SQLite database without applying migrations
+func OpenSQLite(dbPath string) (*SQLiteStore, error) {
+	db, err := openSQLiteDB(sqliteDSN(dbPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	db.SetMaxOpenConns(sqliteMaxOpenConns)
+	db.SetMaxIdleConns(sqliteMaxIdleConns)
+	db.SetConnMaxIdleTime(sqliteConnMaxIdleTime)
 
-	return nil
-}
+	s := &SQLiteStore{sqlStore: &sqlStore{db: db, dialect: sqliteDialect}}
 
-// IsSeen checks if a job has been seen before
-func (s *Storage) IsSeen(jobID string) (bool, error) {
-	var count int
-	err := s.db.QueryRow(
-		"SELECT COUNT(*) FROM jobs_seen WHERE job_id = ?",
-		jobID,
-	).Scan(&count)
-	if err != nil {
-		return false, fmt.Errorf("failed to check if job seen: %w", err)
+	// Test connection. Switching a new database to WAL needs an exclusive
+	// lock that the busy timeout does not cover, so retry it here.
+	if err := s.retry(db.Ping); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
-	return count > 0, nil
-}
 
-// MarkSeen marks a job as seen
-func (s *Storage) MarkSeen(jobID, title, url string) error {
-	_, err := s.db.Exec(`
-		INSERT OR REPLACE INTO jobs_seen 
-		(job_id, job_title, job_url, first_seen_at, notified, created_at)
-		VALUES (?, ?, ?, ?, TRUE, ?)
-	`, jobID, title, url, time.Now(), time.Now())
-	if err != nil {
-		return fmt.Errorf("failed to mark job as seen: %w", err)
-	}
-	return nil
+	return s, nil
 }
 
-// SaveNotifyRecord saves a notification record
-func (s *Storage) SaveNotifyRecord(record *model.NotifyRecord) error {
-	_, err := s.db.Exec(`
-		INSERT INTO notify_records 
-		(job_id, job_title, job_url, search_name, matched_keywords, 
-		 notify_channel, status, error_message, created_at, sent_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, record.JobID, record.JobTitle, record.JobURL, record.SearchName,
-		record.MatchedKeywords, record.NotifyChannel, record.Status,
-		record.ErrorMessage, record.CreatedAt, record.SentAt)
-	if err != nil {
-		return fmt.Errorf("failed to save notify record: %w", err)
-	}
-	return nil
+// searchIndexTriggers names the triggers created by searchIndexSchema
+var searchIndexTriggers = []string{
+	"jobs_fts_insert", "jobs_fts_update", "jobs_fts_delete",
+	"job_skills_fts_insert", "job_skills_fts_delete",
 }
 
-// GetNotifyRecords retrieves notification records
-func (s *Storage) GetNotifyRecords(limit int) ([]*model.NotifyRecord, error) {
-	rows, err := s.db.Query(`
-		SELECT id, job_id, job_title, job_url, search_name, matched_keywords,
-		       notify_channel, status, error_message, created_at, sent_at
-		FROM notify_records
-		ORDER BY created_at DESC
-		LIMIT ?
-	`, limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get notify records: %w", err)
-	}
-	defer rows.Close()
-
-	var records []*model.NotifyRecord
-	for rows.Next() {
-		r := &model.NotifyRecord{}
-		var sentAt sql.NullTime
-		err := rows.Scan(
-			&r.ID, &r.JobID, &r.JobTitle, &r.JobURL, &r.SearchName,
-			&r.MatchedKeywords, &r.NotifyChannel, &r.Status,
-			&r.ErrorMessage, &r.CreatedAt, &sentAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan notify record: %w", err)
-		}
-		if sentAt.Valid {
-			r.SentAt = &sentAt.Time
+// ensureSearchIndex creates the full-text index if this build of SQLite
+// includes FTS5 (build tag sqlite_fts5), and rebuilds it when it is out of
+// step with the jobs table. The index is derived data, so it is managed here
+// rather than in the versioned migrations, which must run on every build.
+func (s *SQLiteStore) ensureSearchIndex() error {
+	var available bool
+	if err := s.db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&available); err != nil {
+		return fmt.Errorf("failed to check for FTS5: %w", err)
+	}
+	if !available {
+		// Triggers left by an FTS5 build would make every job write fail
+		// here; drop them and let the next FTS5 build rebuild the index.
+		for _, name := range searchIndexTriggers {
+			if _, err := s.exec("DROP TRIGGER IF EXISTS " + name); err != nil {
+				return fmt.Errorf("failed to drop search index trigger: %w", err)
+			}
 		}
-		records = append(records, r)
+		s.fts = false
+		return nil
 	}
-	return records, nil
-}
 
-// SaveRunLog saves a run log entry
-func (s *Storage) SaveRunLog(stats *model.RunStats) error {
-	_, err := s.db.Exec(`
-		INSERT INTO run_logs 
-		(started_at, finished_at, jobs_fetched, jobs_matched, jobs_notified, jobs_skipped, error_message)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-	`, stats.StartedAt, stats.FinishedAt, stats.JobsFetched, stats.JobsMatched,
-		stats.JobsNotified, stats.JobsSkipped, stats.ErrorMessage)
+	var triggers int
+	err := s.db.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name IN (?, ?, ?, ?, ?)",
+		searchIndexTriggers[0], searchIndexTriggers[1], searchIndexTriggers[2],
+		searchIndexTriggers[3], searchIndexTriggers[4],
+	).Scan(&triggers)
 	if err != nil {
-		return fmt.Errorf("failed to save run log: %w", err)
+		return fmt.Errorf("failed to inspect search index: %w", err)
 	}
-	return nil
-}
 
-// GetOverallStats retrieves aggregate statistics
-func (s *Storage) GetOverallStats() (*model.OverallStats, error) {
-	stats := &model.OverallStats{}
-
-	// Get totals from run_logs
-	err := s.db.QueryRow(`
-		SELECT COUNT(*), COALESCE(SUM(jobs_fetched), 0), 
-		       COALESCE(SUM(jobs_matched), 0), COALESCE(SUM(jobs_notified), 0)
-		FROM run_logs
-	`).Scan(&stats.TotalRuns, &stats.TotalJobsFetched,
-		&stats.TotalJobsMatched, &stats.TotalJobsNotified)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get overall stats: %w", err)
+	for _, stmt := range searchIndexSchema {
+		if _, err := s.exec(stmt); err != nil {
+			return fmt.Errorf("failed to create search index: %w", err)
+		}
 	}
+	s.fts = true
 
-	// Get last run time
-	var lastRun sql.NullTime
-	err = s.db.QueryRow(`
-		SELECT MAX(started_at) FROM run_logs
-	`).Scan(&lastRun)
-	if err == nil && lastRun.Valid {
-		stats.LastRunAt = &lastRun.Time
+	var jobs, indexed int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM jobs").Scan(&jobs); err != nil {
+		return fmt.Errorf("failed to count jobs: %w", err)
 	}
-
-	// Get last match time
-	var lastMatch sql.NullTime
-	err = s.db.QueryRow(`
-		SELECT MAX(created_at) FROM notify_records WHERE status = 'sent'
-	`).Scan(&lastMatch)
-	if err == nil && lastMatch.Valid {
-		stats.LastMatchAt = &lastMatch.Time
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM jobs_fts").Scan(&indexed); err != nil {
+		return fmt.Errorf("failed to count indexed jobs: %w", err)
 	}
+	if jobs == indexed && triggers == len(searchIndexTriggers) {
+		return nil
+	}
+
+	return s.transact(func(tx *sql.Tx) error {
+		if _, err := tx.Exec("DELETE FROM jobs_fts"); err != nil {
+			return fmt.Errorf("failed to clear search index: %w", err)
+		}
+		_, err := tx.Exec(`
+			INSERT INTO jobs_fts (rowid, title, description, skills)
+			SELECT j.rowid, j.title, j.description,
+				COALESCE((SELECT group_concat(skill, ' ') FROM job_skills s WHERE s.job_id = j.job_id), '')
+			FROM jobs j
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild search index: %w", err)
+		}
+		return nil
+	})
+}
 
-	return stats, nil
+// hasSearchIndex reports whether the database has an FTS5 index this build
+// can read, without creating one as ensureSearchIndex does
+func (s *SQLiteStore) hasSearchIndex() (bool, error) {
+	var available bool
+	if err := s.db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&available); err != nil {
+		return false, fmt.Errorf("failed to check for FTS5: %w", err)
+	}
+	if !available {
+		return false, nil
+	}
+	var tables int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'jobs_fts'").Scan(&tables); err != nil {
+		return false, fmt.Errorf("failed to inspect search index: %w", err)
+	}
+	return tables > 0, nil
 }
 
-// Cleanup removes records older than the specified retention period
-func (s *Storage) Cleanup(retentionDays int) error {
-	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+// FullTextSearch reports whether searches use the FTS5 index. Without it,
+// SearchJobs falls back to unranked substring matching.
+func (s *SQLiteStore) FullTextSearch() bool {
+	return s.fts
+}
 
-	_, err := s.db.Exec(
-		"DELETE FROM jobs_seen WHERE created_at < ?",
-		cutoff,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to cleanup jobs_seen: %w", err)
+// SearchJobs finds stored jobs matching a full-text query, best match first
+func (s *SQLiteStore) SearchJobs(q model.JobSearch) ([]*model.JobSearchResult, error) {
+	if !s.fts {
+		return s.likeSearch(q)
 	}
 
-	_, err = s.db.Exec(
-		"DELETE FROM notify_records WHERE created_at < ?",
-		cutoff,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to cleanup notify_records: %w", err)
+	match := ftsQuery(q.Query)
+	if match == "" {
+		return nil, fmt.Errorf("empty search query")
 	}
 
-	_, err = s.db.Exec(
-		"DELETE FROM run_logs WHERE created_at < ?",
-		cutoff,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to cleanup run_logs: %w", err)
-	}
+	// bm25 is lower for better matches; weight title and skills over description
+	conditions, args := searchConditions(q)
+	args = append([]interface{}{q.HighlightStart, q.HighlightEnd, snippetWords, match}, args...)
 
-	return nil
-}
+	query := `
+		SELECT ` + qualifyColumns(jobColumns, "j") + `,
+			-bm25(jobs_fts, 10.0, 1.0, 5.0) AS rank,
+			snippet(jobs_fts, -1, ?, ?, '…', ?)
+		FROM jobs_fts
+		JOIN jobs j ON j.rowid = jobs_fts.rowid
+		WHERE jobs_fts MATCH ?`
+	for _, c := range conditions {
+		query += " AND " + c
+	}
+	query += " ORDER BY rank DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
 
-// Close closes the database connection
-func (s *Storage) Close() error {
-	return s.db.Close()
+	return s.scanSearchResults(query, args...)
 }
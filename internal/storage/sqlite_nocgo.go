@@ -0,0 +1,21 @@
+//go:build !cgo
+
+package storage
+
+import "database/sql"
+
+func openSQLiteDB(dsn string) (*sql.DB, error) {
+	return nil, ErrSQLiteCGO
+}
+
+func isSQLiteBusy(err error) bool {
+	return false
+}
+
+func isSQLiteCorrupt(err error) bool {
+	return false
+}
+
+func copyDatabase(dest, src *sql.DB) error {
+	return ErrSQLiteCGO
+}
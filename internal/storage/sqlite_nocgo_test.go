@@ -0,0 +1,15 @@
+//go:build !cgo
+
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewSQLite_NoCGO(t *testing.T) {
+	if _, err := NewSQLite(filepath.Join(t.TempDir(), "test.db")); !errors.Is(err, ErrSQLiteCGO) {
+		t.Errorf("NewSQLite() error = %v, want %v", err, ErrSQLiteCGO)
+	}
+}
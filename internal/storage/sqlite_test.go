@@ -0,0 +1,180 @@
+//go:build cgo
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"jobradar/internal/model"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestOpenSQLite_WAL(t *testing.T) {
+	dir := t.TempDir()
+	paths := map[string]string{
+		"path":         filepath.Join(dir, "test.db"),
+		"query string": "file:" + filepath.Join(dir, "uri.db") + "?mode=rwc",
+	}
+	for name, path := range paths {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewSQLite(path)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			defer s.Close()
+
+			var mode string
+			if err := s.db.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+				t.Fatal(err)
+			}
+			if mode != "wal" {
+				t.Errorf("journal_mode = %q, want wal", mode)
+			}
+
+			var timeout int
+			if err := s.db.QueryRow("PRAGMA busy_timeout").Scan(&timeout); err != nil {
+				t.Fatal(err)
+			}
+			if timeout == 0 {
+				t.Error("busy_timeout is not set")
+			}
+		})
+	}
+}
+
+func TestSQLiteDSN(t *testing.T) {
+	if got, want := sqliteDSN("file:x.db?mode=rwc"), "file:x.db?mode=rwc&"+sqliteParams; got != want {
+		t.Errorf("sqliteDSN() = %q, want %q", got, want)
+	}
+	if got, want := sqliteDSN("x.db"), "x.db?"+sqliteParams; got != want {
+		t.Errorf("sqliteDSN() = %q, want %q", got, want)
+	}
+}
+
+func TestSQLiteStore_ConcurrentAccess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	// Two stores on one file behave like the daemon and a CLI command
+	// running side by side, each migrating on open
+	var stores [2]*SQLiteStore
+	var openWG sync.WaitGroup
+	var openErrs [2]error
+	for i := range stores {
+		openWG.Add(1)
+		go func(i int) {
+			defer openWG.Done()
+			stores[i], openErrs[i] = NewSQLite(path)
+		}(i)
+	}
+	openWG.Wait()
+	for i, err := range openErrs {
+		if err != nil {
+			t.Fatalf("NewSQLite() #%d error = %v", i, err)
+		}
+		defer stores[i].Close()
+	}
+
+	const (
+		writers = 4
+		readers = 4
+		rounds  = 25
+	)
+
+	errs := make(chan error, (writers+readers)*rounds)
+	var wg sync.WaitGroup
+
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			s := stores[w%len(stores)]
+			for i := 0; i < rounds; i++ {
+				id := fmt.Sprintf("~01w%dj%d", w, i)
+				now := time.Now()
+				if err := s.MarkSeen(id, "Concurrent Job", "https://example.com"); err != nil {
+					errs <- fmt.Errorf("MarkSeen: %w", err)
+				}
+				err := s.SaveJobs([]*model.JobRecord{{
+					Job:      &model.Job{ID: id, Title: "Concurrent Job", Skills: []string{"Go"}, PostedAt: now},
+					Searches: []string{"Golang"},
+				}})
+				if err != nil {
+					errs <- fmt.Errorf("SaveJobs: %w", err)
+				}
+				err = s.SaveNotifyRecord(&model.NotifyRecord{
+					JobID: id, NotifyChannel: "telegram", Status: model.NotifyStatusSent, CreatedAt: now,
+				})
+				if err != nil {
+					errs <- fmt.Errorf("SaveNotifyRecord: %w", err)
+				}
+				stats := model.NewRunStats()
+				stats.Finish()
+				if err := s.SaveRunLog(stats); err != nil {
+					errs <- fmt.Errorf("SaveRunLog: %w", err)
+				}
+			}
+		}(w)
+	}
+
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			s := stores[r%len(stores)]
+			for i := 0; i < rounds; i++ {
+				if _, err := s.GetNotifyRecords(10); err != nil {
+					errs <- fmt.Errorf("GetNotifyRecords: %w", err)
+				}
+				if _, err := s.QueryJobs(model.JobQuery{SearchName: "Golang", Limit: 10}); err != nil {
+					errs <- fmt.Errorf("QueryJobs: %w", err)
+				}
+				if _, err := s.GetOverallStats(); err != nil {
+					errs <- fmt.Errorf("GetOverallStats: %w", err)
+				}
+			}
+		}(r)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	jobs, err := stores[0].QueryJobs(model.JobQuery{})
+	if err != nil {
+		t.Fatalf("QueryJobs() error = %v", err)
+	}
+	if len(jobs) != writers*rounds {
+		t.Errorf("stored %d jobs, want %d", len(jobs), writers*rounds)
+	}
+}
+
+func TestSQLiteStore_RetryBusy(t *testing.T) {
+	s := &sqlStore{dialect: sqliteDialect}
+
+	busy := sqlite3.Error{Code: sqlite3.ErrBusy}
+	calls := 0
+	err := s.retry(func() error {
+		calls++
+		if calls < 3 {
+			return busy
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("retry() = %v after %d calls, want success after 3", err, calls)
+	}
+
+	calls = 0
+	other := errors.New("constraint failed")
+	if err := s.retry(func() error { calls++; return other }); err != other || calls != 1 {
+		t.Errorf("retry() of a non-busy error = %v after %d calls, want it returned at once", err, calls)
+	}
+}
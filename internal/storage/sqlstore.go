@@ -0,0 +1,562 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+// dialect captures the differences between SQL backends
+type dialect struct {
+	numbered      bool             // Placeholders are $1, $2, ... instead of ?
+	versionTable  string           // DDL for the schema_version table
+	migrations    []migration      // Ordered schema migrations
+	migrationLock string           // Statement serializing migration transactions, empty if they already are
+	isBusy        func(error) bool // Reports errors worth retrying, nil if none are
+}
+
+// sqlStore implements the queries shared by all database/sql backends
+type sqlStore struct {
+	db      *sql.DB
+	dialect dialect
+}
+
+// rebind rewrites ? placeholders for dialects that number them
+func (s *sqlStore) rebind(query string) string {
+	if !s.dialect.numbered {
+		return query
+	}
+
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			sb.WriteString(fmt.Sprintf("$%d", n))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// Retry policy for writes that fail because another process holds a lock
+const (
+	busyRetries    = 5
+	busyRetryDelay = 100 * time.Millisecond
+)
+
+// retry runs fn, running it again with backoff while the database reports
+// it is busy
+func (s *sqlStore) retry(fn func() error) error {
+	delay := busyRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt == busyRetries || s.dialect.isBusy == nil || !s.dialect.isBusy(err) {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// exec runs a write statement, retrying while the database is busy
+func (s *sqlStore) exec(query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := s.retry(func() error {
+		var err error
+		result, err = s.db.Exec(query, args...)
+		return err
+	})
+	return result, err
+}
+
+// transact runs fn in a transaction, retrying the whole transaction while
+// the database is busy
+func (s *sqlStore) transact(fn func(tx *sql.Tx) error) error {
+	return s.retry(func() error {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	})
+}
+
+// IsSeen checks if a job has been seen before
+func (s *sqlStore) IsSeen(jobID string) (bool, error) {
+	var count int
+	err := s.db.QueryRow(
+		s.rebind("SELECT COUNT(*) FROM jobs_seen WHERE job_id = ?"),
+		jobID,
+	).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if job seen: %w", err)
+	}
+	return count > 0, nil
+}
+
+// MarkSeen marks a job as seen
+func (s *sqlStore) MarkSeen(jobID, title, url string) error {
+	_, err := s.exec(s.rebind(`
+		INSERT INTO jobs_seen
+		(job_id, job_title, job_url, first_seen_at, notified, created_at)
+		VALUES (?, ?, ?, ?, TRUE, ?)
+		ON CONFLICT(job_id) DO UPDATE SET
+			job_title = excluded.job_title,
+			job_url = excluded.job_url,
+			first_seen_at = excluded.first_seen_at,
+			notified = excluded.notified,
+			created_at = excluded.created_at
+	`), jobID, title, url, time.Now(), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark job as seen: %w", err)
+	}
+	return nil
+}
+
+// SaveNotifyRecord saves a notification record and sets record.ID
+func (s *sqlStore) SaveNotifyRecord(record *model.NotifyRecord) error {
+	payload, err := encodePayload(record.Job)
+	if err != nil {
+		return err
+	}
+
+	err = s.retry(func() error {
+		return s.db.QueryRow(s.rebind(`
+			INSERT INTO notify_records 
+			(job_id, job_title, job_url, search_name, matched_keywords, 
+			 notify_channel, status, error_message, attempts, created_at,
+			 sent_at, next_attempt_at, payload)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`), record.JobID, record.JobTitle, record.JobURL, record.SearchName,
+			record.MatchedKeywords, record.NotifyChannel, record.Status,
+			record.ErrorMessage, record.Attempts, record.CreatedAt,
+			record.SentAt, record.NextAttemptAt, payload).Scan(&record.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save notify record: %w", err)
+	}
+	return nil
+}
+
+// GetNotifyRecords retrieves notification records
+func (s *sqlStore) GetNotifyRecords(limit int) ([]*model.NotifyRecord, error) {
+	return s.QueryNotifyRecords(model.NotifyQuery{Limit: limit})
+}
+
+// QueryNotifyRecords retrieves notification records matching the query, newest first
+func (s *sqlStore) QueryNotifyRecords(q model.NotifyQuery) ([]*model.NotifyRecord, error) {
+	var records []*model.NotifyRecord
+	err := s.EachNotifyRecord(q, func(r *model.NotifyRecord) error {
+		records = append(records, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// EachNotifyRecord calls fn with each notification record matching the
+// query, newest first, stopping at the first error
+func (s *sqlStore) EachNotifyRecord(q model.NotifyQuery, fn func(*model.NotifyRecord) error) error {
+	var conditions []string
+	var args []interface{}
+
+	if !q.Since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, q.Since)
+	}
+	if !q.Until.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, q.Until)
+	}
+	if q.SearchName != "" {
+		// Jobs matched by several searches store a comma-separated list
+		conditions = append(conditions, `(search_name = ? OR ',' || search_name || ',' LIKE ? ESCAPE '\')`)
+		args = append(args, q.SearchName, "%,"+escapeLike(q.SearchName)+",%")
+	}
+	if q.Channel != "" {
+		conditions = append(conditions, "notify_channel = ?")
+		args = append(args, q.Channel)
+	}
+	if q.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, q.Status)
+	}
+	if q.Stuck {
+		conditions = append(conditions, "(status = ? OR (status = ? AND attempts > 0))")
+		args = append(args, model.NotifyStatusFailed, model.NotifyStatusPending)
+	}
+
+	query := "SELECT " + notifyColumns + " FROM notify_records"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	rows, err := s.db.Query(s.rebind(query), args...)
+	if err != nil {
+		return fmt.Errorf("failed to get notify records: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := scanNotifyRecord(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// SaveSearchMatches saves one record per search that matched a job
+func (s *sqlStore) SaveSearchMatches(matched *model.MatchedJob) error {
+	now := time.Now()
+	return s.transact(func(tx *sql.Tx) error {
+		for _, m := range matched.Matches {
+			_, err := tx.Exec(s.rebind(`
+				INSERT INTO search_matches (job_id, search_name, matched_keywords, match_score, created_at)
+				VALUES (?, ?, ?, ?, ?)
+			`), matched.Job.ID, m.SearchName, strings.Join(m.MatchedKeywords, ","), m.MatchScore, now)
+			if err != nil {
+				return fmt.Errorf("failed to save search match: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetSearchStats retrieves per-search match statistics
+func (s *sqlStore) GetSearchStats() ([]*model.SearchStats, error) {
+	rows, err := s.db.Query(`
+		SELECT search_name, COUNT(*), COALESCE(AVG(match_score), 0), MAX(created_at)
+		FROM search_matches
+		GROUP BY search_name
+		ORDER BY COUNT(*) DESC, search_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search stats: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*model.SearchStats
+	for rows.Next() {
+		st := &model.SearchStats{}
+		var lastMatch interface{}
+		if err := rows.Scan(&st.SearchName, &st.TotalMatches, &st.AvgScore, &lastMatch); err != nil {
+			return nil, fmt.Errorf("failed to scan search stats: %w", err)
+		}
+		if t, ok := timeValue(lastMatch); ok {
+			st.LastMatchAt = &t
+		}
+		result = append(result, st)
+	}
+	return result, rows.Err()
+}
+
+// QuerySearchMatches retrieves the search matches recorded for notified
+// jobs between since and until, oldest first. Zero times are unbounded.
+func (s *sqlStore) QuerySearchMatches(since, until time.Time) ([]*model.SearchMatchRecord, error) {
+	var conditions []string
+	var args []interface{}
+
+	if !since.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, since)
+	}
+	if !until.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, until)
+	}
+
+	query := `
+		SELECT job_id, search_name, COALESCE(matched_keywords, ''), match_score, created_at
+		FROM search_matches`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at"
+
+	rows, err := s.db.Query(s.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search matches: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*model.SearchMatchRecord
+	for rows.Next() {
+		m := &model.SearchMatchRecord{}
+		var keywords string
+		if err := rows.Scan(&m.JobID, &m.SearchName, &keywords, &m.MatchScore, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan search match: %w", err)
+		}
+		if keywords != "" {
+			m.MatchedKeywords = strings.Split(keywords, ",")
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+// SaveRunLog saves a run log entry with its source results and sets stats.ID
+func (s *sqlStore) SaveRunLog(stats *model.RunStats) error {
+	return s.transact(func(tx *sql.Tx) error {
+		err := tx.QueryRow(s.rebind(`
+			INSERT INTO run_logs 
+			(started_at, finished_at, jobs_fetched, jobs_matched, jobs_notified, jobs_skipped, error_message)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`), stats.StartedAt, stats.FinishedAt, stats.JobsFetched, stats.JobsMatched,
+			stats.JobsNotified, stats.JobsSkipped, stats.ErrorMessage).Scan(&stats.ID)
+		if err != nil {
+			return fmt.Errorf("failed to save run log: %w", err)
+		}
+
+		for _, r := range stats.Sources {
+			_, err := tx.Exec(s.rebind(`
+				INSERT INTO run_source_results
+				(run_id, source, search_name, keyword, started_at, duration_seconds,
+				 fetched, http_status, error_message)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`), stats.ID, r.Source, r.SearchName, r.Keyword, r.StartedAt, r.DurationSeconds,
+				r.Fetched, r.HTTPStatus, r.ErrorMessage)
+			if err != nil {
+				return fmt.Errorf("failed to save source result: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetRunLog retrieves a run log with its source results, returning nil if
+// it does not exist
+func (s *sqlStore) GetRunLog(id int64) (*model.RunStats, error) {
+	var run *model.RunStats
+	err := s.eachRunLog("id = ?", []interface{}{id}, "", func(r *model.RunStats) error {
+		run = r
+		return nil
+	})
+	if err != nil || run == nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(s.rebind(`
+		SELECT source, COALESCE(search_name, ''), COALESCE(keyword, ''), started_at,
+		       duration_seconds, fetched, COALESCE(http_status, 0), COALESCE(error_message, '')
+		FROM run_source_results
+		WHERE run_id = ?
+		ORDER BY id
+	`), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source results: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r := &model.SourceResult{}
+		err := rows.Scan(&r.Source, &r.SearchName, &r.Keyword, &r.StartedAt,
+			&r.DurationSeconds, &r.Fetched, &r.HTTPStatus, &r.ErrorMessage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan source result: %w", err)
+		}
+		run.Sources = append(run.Sources, r)
+	}
+	return run, rows.Err()
+}
+
+// QueryRunLogs retrieves run logs matching the query, newest first
+func (s *sqlStore) QueryRunLogs(q model.RunLogQuery) ([]*model.RunStats, error) {
+	var logs []*model.RunStats
+	err := s.EachRunLog(q, func(r *model.RunStats) error {
+		logs = append(logs, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// EachRunLog calls fn with each run log matching the query, newest first,
+// stopping at the first error
+func (s *sqlStore) EachRunLog(q model.RunLogQuery, fn func(*model.RunStats) error) error {
+	var conditions []string
+	var args []interface{}
+
+	if !q.Since.IsZero() {
+		conditions = append(conditions, "started_at >= ?")
+		args = append(args, q.Since)
+	}
+	if !q.Until.IsZero() {
+		conditions = append(conditions, "started_at < ?")
+		args = append(args, q.Until)
+	}
+	if q.Failed {
+		conditions = append(conditions, "COALESCE(error_message, '') <> ''")
+	}
+
+	limit := ""
+	if q.Limit > 0 {
+		limit = " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+	return s.eachRunLog(strings.Join(conditions, " AND "), args, limit, fn)
+}
+
+// eachRunLog calls fn with each run log matching where, newest first
+func (s *sqlStore) eachRunLog(where string, args []interface{}, limit string, fn func(*model.RunStats) error) error {
+	query := `
+		SELECT id, started_at, finished_at, jobs_fetched, jobs_matched,
+		       jobs_notified, jobs_skipped, COALESCE(error_message, '')
+		FROM run_logs`
+	if where != "" {
+		query += " WHERE " + where
+	}
+	query += " ORDER BY started_at DESC" + limit
+
+	rows, err := s.db.Query(s.rebind(query), args...)
+	if err != nil {
+		return fmt.Errorf("failed to get run logs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r := &model.RunStats{}
+		var finishedAt sql.NullTime
+		err := rows.Scan(
+			&r.ID, &r.StartedAt, &finishedAt, &r.JobsFetched, &r.JobsMatched,
+			&r.JobsNotified, &r.JobsSkipped, &r.ErrorMessage,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan run log: %w", err)
+		}
+		if finishedAt.Valid {
+			r.FinishedAt = &finishedAt.Time
+			r.DurationSeconds = finishedAt.Time.Sub(r.StartedAt).Seconds()
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetOverallStats retrieves aggregate statistics
+func (s *sqlStore) GetOverallStats() (*model.OverallStats, error) {
+	stats := &model.OverallStats{}
+
+	// Get totals from run_logs plus the daily totals of deleted runs
+	err := s.db.QueryRow(`
+		SELECT COALESCE(SUM(runs), 0), COALESCE(SUM(fetched), 0),
+		       COALESCE(SUM(matched), 0), COALESCE(SUM(notified), 0)
+		FROM (
+			SELECT COUNT(*) AS runs, SUM(jobs_fetched) AS fetched,
+			       SUM(jobs_matched) AS matched, SUM(jobs_notified) AS notified
+			FROM run_logs
+			UNION ALL
+			SELECT SUM(runs), SUM(jobs_fetched), SUM(jobs_matched), SUM(jobs_notified)
+			FROM run_log_daily
+		) totals
+	`).Scan(&stats.TotalRuns, &stats.TotalJobsFetched,
+		&stats.TotalJobsMatched, &stats.TotalJobsNotified)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overall stats: %w", err)
+	}
+
+	// Get first and last run time
+	var firstRun, lastRun, firstRolled, lastRolled interface{}
+	err = s.db.QueryRow(`
+		SELECT MIN(started_at), MAX(started_at) FROM run_logs
+	`).Scan(&firstRun, &lastRun)
+	if err == nil {
+		err = s.db.QueryRow(`
+			SELECT MIN(first_started_at), MAX(last_started_at) FROM run_log_daily
+		`).Scan(&firstRolled, &lastRolled)
+	}
+	if err == nil {
+		stats.TrackingSince = earliest(firstRun, firstRolled)
+		stats.LastRunAt = latest(lastRun, lastRolled)
+	}
+
+	// Get last match time
+	var lastMatch interface{}
+	err = s.db.QueryRow(`
+		SELECT MAX(created_at) FROM notify_records WHERE status = 'sent'
+	`).Scan(&lastMatch)
+	if t, ok := timeValue(lastMatch); err == nil && ok {
+		stats.LastMatchAt = &t
+	}
+
+	return stats, nil
+}
+
+// Close closes the database connection
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+// earliest returns the earliest of the given aggregate time values, if any
+func earliest(values ...interface{}) *time.Time {
+	var result *time.Time
+	for _, v := range values {
+		if t, ok := timeValue(v); ok && (result == nil || t.Before(*result)) {
+			result = &t
+		}
+	}
+	return result
+}
+
+// latest returns the latest of the given aggregate time values, if any
+func latest(values ...interface{}) *time.Time {
+	var result *time.Time
+	for _, v := range values {
+		if t, ok := timeValue(v); ok && (result == nil || t.After(*result)) {
+			result = &t
+		}
+	}
+	return result
+}
+
+// timeValue converts a scanned timestamp to time.Time. Aggregates such as
+// MAX() come back as text from SQLite because they lose the column type.
+func timeValue(v interface{}) (time.Time, bool) {
+	var text string
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		text = t
+	case []byte:
+		text = string(t)
+	default:
+		return time.Time{}, false
+	}
+
+	for _, layout := range sqliteTimestampFormats {
+		if t, err := time.ParseInLocation(layout, text, time.UTC); err == nil {
+			return t.Local(), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+// skipWithoutCGO skips a test that needs SQLite when err says this build
+// has no cgo
+func skipWithoutCGO(t *testing.T, err error) {
+	t.Helper()
+	if errors.Is(err, ErrSQLiteCGO) {
+		t.Skip(err)
+	}
+}
+
+func TestSQLiteStore_QueryNotifyRecords(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	now := time.Now()
+	records := []*model.NotifyRecord{
+		{JobID: "~01a", SearchName: "Golang API", NotifyChannel: "telegram", Status: model.NotifyStatusSent, CreatedAt: now},
+		{JobID: "~01b", SearchName: "React,Golang API", NotifyChannel: "email", Status: model.NotifyStatusFailed, CreatedAt: now},
+		{JobID: "~01c", SearchName: "Golang API v2", NotifyChannel: "telegram", Status: model.NotifyStatusSent, CreatedAt: now.AddDate(0, 0, -10)},
+	}
+	for _, r := range records {
+		if err := s.SaveNotifyRecord(r); err != nil {
+			t.Fatalf("SaveNotifyRecord() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query model.NotifyQuery
+		want  int
+	}{
+		{"all", model.NotifyQuery{}, 3},
+		{"search in list", model.NotifyQuery{SearchName: "Golang API"}, 2},
+		{"channel", model.NotifyQuery{Channel: "telegram"}, 2},
+		{"status", model.NotifyQuery{Status: model.NotifyStatusFailed}, 1},
+		{"since", model.NotifyQuery{Since: now.AddDate(0, 0, -1)}, 2},
+		{"until", model.NotifyQuery{Until: now.AddDate(0, 0, -1)}, 1},
+		{"limit", model.NotifyQuery{Limit: 1}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.QueryNotifyRecords(tt.query)
+			if err != nil {
+				t.Fatalf("QueryNotifyRecords() error = %v", err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("QueryNotifyRecords() returned %d records, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteStore_EachJob(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	var records []*model.JobRecord
+	for _, id := range []string{"~01a", "~01b", "~01c"} {
+		records = append(records, &model.JobRecord{Job: &model.Job{ID: id, Title: id, Skills: []string{"Go", "SQL"}}})
+	}
+	if err := s.SaveJobs(records); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+
+	stop := errors.New("stop")
+	var seen []*model.JobRecord
+	err = s.EachJob(model.JobQuery{}, func(r *model.JobRecord) error {
+		seen = append(seen, r)
+		if len(seen) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("EachJob() error = %v, want the callback's error", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("EachJob() called back %d times, want 2", len(seen))
+	}
+	if len(seen[0].Job.Skills) != 2 {
+		t.Errorf("skills = %v, want them loaded while streaming", seen[0].Job.Skills)
+	}
+}
+
+func TestSQLiteStore_RunSourceResults(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	ok := model.NewRunStats()
+	ok.Sources = []*model.SourceResult{
+		{Source: model.SourceAPI, SearchName: "Golang", Keyword: "golang", StartedAt: ok.StartedAt, Fetched: 12, HTTPStatus: 200},
+	}
+	ok.Finish()
+
+	failed := model.NewRunStats()
+	failed.Sources = []*model.SourceResult{
+		{Source: model.SourceAPI, SearchName: "Golang", Keyword: "golang", StartedAt: failed.StartedAt, Fetched: 10, HTTPStatus: 200},
+		{Source: model.SourceAPI, SearchName: "Golang", Keyword: "grpc", StartedAt: failed.StartedAt, HTTPStatus: 429, ErrorMessage: "API returned status 429"},
+	}
+	failed.ErrorMessage = "1 of 2 sources failed"
+	failed.Finish()
+
+	for _, run := range []*model.RunStats{ok, failed} {
+		if err := s.SaveRunLog(run); err != nil {
+			t.Fatalf("SaveRunLog() error = %v", err)
+		}
+	}
+	if ok.ID == 0 || failed.ID == ok.ID {
+		t.Fatalf("SaveRunLog() set IDs %d and %d, want distinct IDs", ok.ID, failed.ID)
+	}
+
+	run, err := s.GetRunLog(failed.ID)
+	if err != nil {
+		t.Fatalf("GetRunLog() error = %v", err)
+	}
+	if len(run.Sources) != 2 || run.Sources[1].HTTPStatus != 429 || !run.Sources[1].Failed() {
+		t.Errorf("GetRunLog() sources = %+v, want the failed grpc fetch second", run.Sources)
+	}
+	if missing, err := s.GetRunLog(failed.ID + 100); err != nil || missing != nil {
+		t.Errorf("GetRunLog(missing) = %v, %v, want nil, nil", missing, err)
+	}
+
+	logs, err := s.QueryRunLogs(model.RunLogQuery{Failed: true})
+	if err != nil {
+		t.Fatalf("QueryRunLogs() error = %v", err)
+	}
+	if len(logs) != 1 || logs[0].ID != failed.ID {
+		t.Errorf("QueryRunLogs(failed) = %d runs, want only the failed run", len(logs))
+	}
+
+	// Rolling the runs up into daily totals removes their source results too
+	if err := s.rollupRunLogs(time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("rollupRunLogs() error = %v", err)
+	}
+	var remaining int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM run_source_results").Scan(&remaining); err != nil {
+		t.Fatal(err)
+	}
+	if remaining != 0 {
+		t.Errorf("%d source results left after their runs were rolled up", remaining)
+	}
+}
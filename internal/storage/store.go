@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"time"
+
+	"jobradar/internal/config"
+	"jobradar/internal/model"
+)
+
+// Store is the persistence layer used by the engine and CLI commands
+type Store interface {
+	// Seen jobs (deduplication)
+	IsSeen(jobID string) (bool, error)
+	MarkSeen(jobID, title, url string) error
+
+	// Notification records
+	SaveNotifyRecord(record *model.NotifyRecord) error
+	GetNotifyRecords(limit int) ([]*model.NotifyRecord, error)
+	QueryNotifyRecords(q model.NotifyQuery) ([]*model.NotifyRecord, error)
+	EachNotifyRecord(q model.NotifyQuery, fn func(*model.NotifyRecord) error) error
+	DueNotifications(now time.Time, limit int) ([]*model.NotifyRecord, error)
+	ClaimNotification(id int64, now, until time.Time) (bool, error)
+	UpdateNotifyRecord(record *model.NotifyRecord) error
+
+	// Search matches
+	SaveSearchMatches(matched *model.MatchedJob) error
+	GetSearchStats() ([]*model.SearchStats, error)
+	QuerySearchMatches(since, until time.Time) ([]*model.SearchMatchRecord, error)
+
+	// Job records
+	SaveJobs(records []*model.JobRecord) error
+	GetJob(jobID string) (*model.JobRecord, error)
+	QueryJobs(q model.JobQuery) ([]*model.JobRecord, error)
+	EachJob(q model.JobQuery, fn func(*model.JobRecord) error) error
+
+	// Full-text search
+	SearchJobs(q model.JobSearch) ([]*model.JobSearchResult, error)
+	FullTextSearch() bool
+
+	// Job tracking
+	UpdateTrackedJob(u model.TrackUpdate) (*model.TrackedJob, error)
+	GetTrackedJob(ref string) (*model.TrackedJob, error)
+	QueryTrackedJobs(q model.TrackQuery) ([]*model.TrackedJob, error)
+	GetWinRates() (*model.WinRateReport, error)
+
+	// Run logs
+	SaveRunLog(stats *model.RunStats) error
+	QueryRunLogs(q model.RunLogQuery) ([]*model.RunStats, error)
+	EachRunLog(q model.RunLogQuery, fn func(*model.RunStats) error) error
+	GetRunLog(id int64) (*model.RunStats, error)
+	GetOverallStats() (*model.OverallStats, error)
+
+	// Maintenance
+	Cleanup(retention config.RetentionConfig) error
+	Backup(dest string) error
+	Restore(src string) error
+	Vacuum() error
+	Check() ([]string, error)
+	Migrate() error
+	MigrateTo(target int) error
+	SchemaVersion() (int, error)
+	LatestVersion() int
+	MigrationStatus() ([]MigrationStatus, error)
+	Close() error
+}
+
+// New opens the configured backend and applies any pending migrations.
+// PostgreSQL is used when a DSN is configured, SQLite otherwise.
+func New(cfg config.StorageConfig) (Store, error) {
+	if cfg.DSN != "" {
+		return NewPostgres(cfg.DSN)
+	}
+	return NewSQLite(cfg.Database)
+}
+
+// Open opens the configured backend without applying migrations
+func Open(cfg config.StorageConfig) (Store, error) {
+	if cfg.DSN != "" {
+		return OpenPostgres(cfg.DSN)
+	}
+	return OpenSQLite(cfg.Database)
+}
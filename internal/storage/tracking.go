@@ -0,0 +1,376 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+// trackColumns lists the job_tracking columns in scan order
+const trackColumns = `job_id, job_title, job_url, search_names, keywords, status,
+	bid, connects, created_at, updated_at, applied_at, closed_at`
+
+// queryer is implemented by *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// UpdateTrackedJob applies an update to a tracked job, starting to track it
+// if needed, and returns the job as stored
+func (s *sqlStore) UpdateTrackedJob(u model.TrackUpdate) (*model.TrackedJob, error) {
+	var jobID string
+	err := s.transact(func(tx *sql.Tx) error {
+		now := time.Now()
+
+		t, err := s.trackedJob(tx, u.JobRef)
+		if err != nil {
+			return err
+		}
+		isNew := t == nil
+		if isNew {
+			if t, err = s.snapshotJob(tx, u.JobRef); err != nil {
+				return err
+			}
+			t.CreatedAt = now
+			if u.Status == "" {
+				u.Status = model.TrackStatusNew
+			}
+		}
+		jobID = t.JobID
+
+		if u.Status != "" && u.Status != t.Status {
+			t.Status = u.Status
+			if t.Status.Applied() && t.AppliedAt == nil {
+				t.AppliedAt = &now
+			}
+			t.ClosedAt = nil
+			if t.Status.Closed() {
+				t.ClosedAt = &now
+			}
+			_, err := tx.Exec(s.rebind(`
+				INSERT INTO job_status_changes (job_id, status, changed_at) VALUES (?, ?, ?)
+			`), t.JobID, t.Status, now)
+			if err != nil {
+				return fmt.Errorf("failed to save status change: %w", err)
+			}
+		}
+		if u.Bid != nil {
+			t.Bid = u.Bid
+		}
+		if u.Connects != nil {
+			t.Connects = *u.Connects
+		}
+		t.UpdatedAt = now
+
+		if isNew {
+			_, err = tx.Exec(s.rebind(`
+				INSERT INTO job_tracking (`+trackColumns+`)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			`), t.JobID, t.JobTitle, t.JobURL, strings.Join(t.Searches, ","), strings.Join(t.Keywords, ","),
+				t.Status, t.Bid, t.Connects, t.CreatedAt, t.UpdatedAt, t.AppliedAt, t.ClosedAt)
+		} else {
+			_, err = tx.Exec(s.rebind(`
+				UPDATE job_tracking
+				SET status = ?, bid = ?, connects = ?, updated_at = ?, applied_at = ?, closed_at = ?
+				WHERE job_id = ?
+			`), t.Status, t.Bid, t.Connects, t.UpdatedAt, t.AppliedAt, t.ClosedAt, t.JobID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save tracked job: %w", err)
+		}
+
+		if u.Note != "" {
+			_, err := tx.Exec(s.rebind(`
+				INSERT INTO job_notes (job_id, body, created_at) VALUES (?, ?, ?)
+			`), t.JobID, u.Note, now)
+			if err != nil {
+				return fmt.Errorf("failed to save note: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTrackedJob(jobID)
+}
+
+// GetTrackedJob retrieves a tracked job by ID or URL with its notes and
+// status history, returning nil if the job is not tracked
+func (s *sqlStore) GetTrackedJob(ref string) (*model.TrackedJob, error) {
+	t, err := s.trackedJob(s.db, ref)
+	if err != nil || t == nil {
+		return t, err
+	}
+
+	rows, err := s.db.Query(s.rebind(`
+		SELECT id, body, created_at FROM job_notes WHERE job_id = ? ORDER BY created_at, id
+	`), t.JobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job notes: %w", err)
+	}
+	for rows.Next() {
+		var n model.JobNote
+		if err := rows.Scan(&n.ID, &n.Body, &n.CreatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan job note: %w", err)
+		}
+		t.Notes = append(t.Notes, n)
+	}
+	rows.Close()
+
+	rows, err = s.db.Query(s.rebind(`
+		SELECT status, changed_at FROM job_status_changes WHERE job_id = ? ORDER BY changed_at, id
+	`), t.JobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c model.StatusChange
+		if err := rows.Scan(&c.Status, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		t.History = append(t.History, c)
+	}
+	return t, rows.Err()
+}
+
+// QueryTrackedJobs lists tracked jobs matching the query, most recently updated first
+func (s *sqlStore) QueryTrackedJobs(q model.TrackQuery) ([]*model.TrackedJob, error) {
+	var conditions []string
+	var args []interface{}
+
+	if len(q.Statuses) > 0 {
+		placeholders := make([]string, len(q.Statuses))
+		for i, status := range q.Statuses {
+			placeholders[i] = "?"
+			args = append(args, status)
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if q.SearchName != "" {
+		conditions = append(conditions, `',' || search_names || ',' LIKE ? ESCAPE '\'`)
+		args = append(args, "%,"+escapeLike(q.SearchName)+",%")
+	}
+
+	query := "SELECT " + trackColumns + " FROM job_tracking"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY updated_at DESC"
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	rows, err := s.db.Query(s.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tracked jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*model.TrackedJob
+	for rows.Next() {
+		t, err := scanTrackedJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tracked job: %w", err)
+		}
+		jobs = append(jobs, t)
+	}
+	return jobs, rows.Err()
+}
+
+// GetWinRates summarizes tracked jobs overall, by search and by keyword
+func (s *sqlStore) GetWinRates() (*model.WinRateReport, error) {
+	jobs, err := s.QueryTrackedJobs(model.TrackQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.WinRateReport{Overall: model.WinRate{Name: "overall"}}
+	bySearch := make(map[string]*model.WinRate)
+	byKeyword := make(map[string]*model.WinRate)
+	bids := make(map[*model.WinRate][]float64)
+
+	add := func(w *model.WinRate, t *model.TrackedJob) {
+		w.Tracked++
+		w.Connects += t.Connects
+		if t.Status.Applied() {
+			w.Applied++
+			if t.Bid != nil {
+				bids[w] = append(bids[w], *t.Bid)
+			}
+		}
+		switch t.Status {
+		case model.TrackStatusInterviewing:
+			w.Interviewing++
+		case model.TrackStatusHired:
+			w.Hired++
+		case model.TrackStatusLost:
+			w.Lost++
+		}
+	}
+	group := func(groups map[string]*model.WinRate, list *[]*model.WinRate, name string) *model.WinRate {
+		key := strings.ToLower(name)
+		w, ok := groups[key]
+		if !ok {
+			w = &model.WinRate{Name: name}
+			groups[key] = w
+			*list = append(*list, w)
+		}
+		return w
+	}
+
+	for _, t := range jobs {
+		add(&report.Overall, t)
+		for _, name := range t.Searches {
+			add(group(bySearch, &report.BySearch, name), t)
+		}
+		for _, kw := range t.Keywords {
+			add(group(byKeyword, &report.ByKeyword, kw), t)
+		}
+	}
+
+	for w, values := range bids {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		w.AvgBid = sum / float64(len(values))
+	}
+
+	// Most proposals first, so the best-supported rates lead
+	for _, list := range [][]*model.WinRate{report.BySearch, report.ByKeyword} {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Applied != list[j].Applied {
+				return list[i].Applied > list[j].Applied
+			}
+			return list[i].Name < list[j].Name
+		})
+	}
+	return report, nil
+}
+
+// trackedJob loads a tracked job by ID or URL without notes or history,
+// returning nil if it is not tracked
+func (s *sqlStore) trackedJob(q queryer, ref string) (*model.TrackedJob, error) {
+	t, err := scanTrackedJob(q.QueryRow(s.rebind(
+		"SELECT "+trackColumns+" FROM job_tracking WHERE job_id = ? OR job_url = ?",
+	), ref, ref))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracked job: %w", err)
+	}
+	return t, nil
+}
+
+// snapshotJob builds an untracked record for a job JobRadar has seen,
+// looked up by ID or URL in stored jobs, seen jobs and notifications
+func (s *sqlStore) snapshotJob(q queryer, ref string) (*model.TrackedJob, error) {
+	lookups := []string{
+		"SELECT job_id, COALESCE(title, ''), COALESCE(url, '') FROM jobs WHERE job_id = ? OR url = ?",
+		"SELECT job_id, COALESCE(job_title, ''), COALESCE(job_url, '') FROM jobs_seen WHERE job_id = ? OR job_url = ?",
+		"SELECT job_id, COALESCE(job_title, ''), COALESCE(job_url, '') FROM notify_records WHERE job_id = ? OR job_url = ? ORDER BY created_at DESC LIMIT 1",
+	}
+
+	t := &model.TrackedJob{}
+	found := false
+	for _, lookup := range lookups {
+		err := q.QueryRow(s.rebind(lookup), ref, ref).Scan(&t.JobID, &t.JobTitle, &t.JobURL)
+		if err == nil {
+			found = true
+			break
+		}
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to look up job: %w", err)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("job %s not found; only jobs JobRadar has fetched can be tracked", ref)
+	}
+
+	// Matches live in job_searches while the job is stored and in
+	// search_matches for as long as match history is kept
+	rows, err := q.Query(s.rebind(`
+		SELECT search_name, COALESCE(matched_keywords, '') FROM job_searches WHERE job_id = ? AND matched
+		UNION ALL
+		SELECT search_name, COALESCE(matched_keywords, '') FROM search_matches WHERE job_id = ?
+	`), t.JobID, t.JobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job matches: %w", err)
+	}
+	defer rows.Close()
+
+	var searches, keywords []string
+	for rows.Next() {
+		var search, matched string
+		if err := rows.Scan(&search, &matched); err != nil {
+			return nil, fmt.Errorf("failed to scan job match: %w", err)
+		}
+		searches = append(searches, search)
+		if matched != "" {
+			keywords = append(keywords, strings.Split(matched, ",")...)
+		}
+	}
+	t.Searches = dedupeFold(searches)
+	t.Keywords = dedupeFold(keywords)
+	return t, rows.Err()
+}
+
+// scanTrackedJob scans a job_tracking row selected with trackColumns
+func scanTrackedJob(row rowScanner) (*model.TrackedJob, error) {
+	t := &model.TrackedJob{}
+	var title, url, searches, keywords sql.NullString
+	var bid sql.NullFloat64
+	var appliedAt, closedAt sql.NullTime
+	err := row.Scan(
+		&t.JobID, &title, &url, &searches, &keywords, &t.Status,
+		&bid, &t.Connects, &t.CreatedAt, &t.UpdatedAt, &appliedAt, &closedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	t.JobTitle = title.String
+	t.JobURL = url.String
+	if searches.String != "" {
+		t.Searches = strings.Split(searches.String, ",")
+	}
+	if keywords.String != "" {
+		t.Keywords = strings.Split(keywords.String, ",")
+	}
+	if bid.Valid {
+		t.Bid = &bid.Float64
+	}
+	if appliedAt.Valid {
+		t.AppliedAt = &appliedAt.Time
+	}
+	if closedAt.Valid {
+		t.ClosedAt = &closedAt.Time
+	}
+	return t, nil
+}
+
+// dedupeFold removes blank and case-insensitively repeated values, keeping order
+func dedupeFold(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var result []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		key := strings.ToLower(v)
+		if v != "" && !seen[key] {
+			seen[key] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"jobradar/internal/model"
+)
+
+func TestSQLiteStore_TrackJobs(t *testing.T) {
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "test.db"))
+	skipWithoutCGO(t, err)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer s.Close()
+
+	now := time.Now()
+	records := []*model.JobRecord{
+		{Job: &model.Job{ID: "~01won", Title: "Go API", URL: "https://www.upwork.com/jobs/~01won", PostedAt: now},
+			Searches: []string{"Golang"},
+			Matches:  []model.SearchMatch{{SearchName: "Golang", MatchedKeywords: []string{"golang", "api"}, MatchScore: 1}}},
+		{Job: &model.Job{ID: "~01lost", Title: "Go CLI", PostedAt: now},
+			Searches: []string{"Golang"},
+			Matches:  []model.SearchMatch{{SearchName: "Golang", MatchedKeywords: []string{"golang"}, MatchScore: 1}}},
+	}
+	if err := s.SaveJobs(records); err != nil {
+		t.Fatalf("SaveJobs() error = %v", err)
+	}
+	if err := s.MarkSeen("~01seen", "Seen Only", "https://example.com/seen"); err != nil {
+		t.Fatalf("MarkSeen() error = %v", err)
+	}
+
+	bid, connects := 450.0, 16
+	tracked, err := s.UpdateTrackedJob(model.TrackUpdate{
+		JobRef: "https://www.upwork.com/jobs/~01won", Status: model.TrackStatusApplied,
+		Bid: &bid, Connects: &connects, Note: "sent portfolio",
+	})
+	if err != nil {
+		t.Fatalf("UpdateTrackedJob() error = %v", err)
+	}
+	if tracked.JobID != "~01won" || tracked.AppliedAt == nil || len(tracked.Notes) != 1 {
+		t.Errorf("UpdateTrackedJob() = %+v, want applied job with a note", tracked)
+	}
+	if len(tracked.Keywords) != 2 || tracked.Searches[0] != "Golang" {
+		t.Errorf("tracked matches = %v / %v, want copied from the job", tracked.Searches, tracked.Keywords)
+	}
+
+	steps := []model.TrackUpdate{
+		{JobRef: "~01won", Status: model.TrackStatusHired},
+		{JobRef: "~01lost", Status: model.TrackStatusApplied, Connects: &connects},
+		{JobRef: "~01lost", Status: model.TrackStatusLost},
+		{JobRef: "~01seen", Note: "maybe later"},
+	}
+	for _, u := range steps {
+		if _, err := s.UpdateTrackedJob(u); err != nil {
+			t.Fatalf("UpdateTrackedJob(%+v) error = %v", u, err)
+		}
+	}
+
+	if _, err := s.UpdateTrackedJob(model.TrackUpdate{JobRef: "~01unknown", Status: model.TrackStatusSaved}); err == nil {
+		t.Error("tracking a job JobRadar never saw should fail")
+	}
+
+	won, err := s.GetTrackedJob("~01won")
+	if err != nil {
+		t.Fatalf("GetTrackedJob() error = %v", err)
+	}
+	if won.Status != model.TrackStatusHired || won.ClosedAt == nil || len(won.History) != 2 {
+		t.Errorf("GetTrackedJob() = %+v, want hired with 2 status changes", won)
+	}
+	if *won.AppliedAt != *tracked.AppliedAt {
+		t.Error("applied_at changed after the job moved past applied")
+	}
+
+	seen, _ := s.GetTrackedJob("~01seen")
+	if seen == nil || seen.Status != model.TrackStatusNew || seen.JobTitle != "Seen Only" {
+		t.Errorf("noted job = %+v, want tracked as new", seen)
+	}
+
+	open, err := s.QueryTrackedJobs(model.TrackQuery{Statuses: []model.TrackStatus{model.TrackStatusNew}})
+	if err != nil {
+		t.Fatalf("QueryTrackedJobs() error = %v", err)
+	}
+	if len(open) != 1 {
+		t.Errorf("QueryTrackedJobs(new) returned %d jobs, want 1", len(open))
+	}
+	golang, _ := s.QueryTrackedJobs(model.TrackQuery{SearchName: "Golang"})
+	if len(golang) != 2 {
+		t.Errorf("QueryTrackedJobs(search) returned %d jobs, want 2", len(golang))
+	}
+
+	report, err := s.GetWinRates()
+	if err != nil {
+		t.Fatalf("GetWinRates() error = %v", err)
+	}
+	if o := report.Overall; o.Tracked != 3 || o.Applied != 2 || o.Hired != 1 || o.Lost != 1 || o.Connects != 32 {
+		t.Errorf("overall = %+v", o)
+	}
+	if len(report.BySearch) != 1 || report.BySearch[0].Rate() != 0.5 {
+		t.Errorf("by search = %+v, want Golang at 50%%", report.BySearch)
+	}
+	for _, w := range report.ByKeyword {
+		if w.Name == "api" && (w.Applied != 1 || w.Rate() != 1) {
+			t.Errorf("keyword api = %+v, want 1 of 1 won", w)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Package templates renders notification messages from Go templates.
+// Each channel has a built-in default embedded from defaults/, which a
+// user-supplied template file can replace.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+	"unicode/utf8"
+
+	"jobradar/internal/langdetect"
+	"jobradar/internal/model"
+)
+
+//go:embed defaults
+var defaults embed.FS
+
+// Built-in template names, as listed by Defaults
+const (
+	TelegramName     = "telegram.tmpl"
+	EmailBodyName    = "email.html"
+	EmailTextName    = "email.txt"
+	EmailSubjectName = "email_subject.tmpl"
+)
+
+// Format selects how a template is parsed and what highlight produces
+type Format int
+
+const (
+	Markdown Format = iota // Telegram MarkdownV2 text
+	HTML                   // Auto-escaped by html/template
+	Plain                  // Single-line text such as an email subject
+	Text                   // Multi-line plain text such as an email's text part
+)
+
+// Template is a parsed notification template. It is executed with the
+// *model.MatchedJob being notified as its data.
+type Template struct {
+	name   string
+	format Format
+	text   *texttemplate.Template
+	html   *htmltemplate.Template
+}
+
+// Telegram loads the Telegram message template from path, or the
+// built-in one when path is empty
+func Telegram(path string) (*Template, error) {
+	return load(path, TelegramName, Markdown)
+}
+
+// EmailBody loads the HTML email body template from path, or the
+// built-in one when path is empty
+func EmailBody(path string) (*Template, error) {
+	return load(path, EmailBodyName, HTML)
+}
+
+// EmailText loads the plain text email body template from path, or the
+// built-in one when path is empty
+func EmailText(path string) (*Template, error) {
+	return load(path, EmailTextName, Text)
+}
+
+// EmailSubject parses an inline email subject template, or the built-in
+// one when text is empty
+func EmailSubject(text string) (*Template, error) {
+	if text == "" {
+		return load("", EmailSubjectName, Plain)
+	}
+	return Parse("subject", text, Plain)
+}
+
+// Default returns the source of a built-in template
+func Default(name string) (string, error) {
+	data, err := defaults.ReadFile("defaults/" + name)
+	if err != nil {
+		return "", fmt.Errorf("unknown template %q", name)
+	}
+	return string(data), nil
+}
+
+// Defaults lists the built-in template names
+func Defaults() []string {
+	return []string{TelegramName, EmailBodyName, EmailTextName, EmailSubjectName}
+}
+
+// load reads a template file, falling back to the named default
+func load(path, defaultName string, format Format) (*Template, error) {
+	if path == "" {
+		src, err := Default(defaultName)
+		if err != nil {
+			return nil, err
+		}
+		return Parse(defaultName, src, format)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	return Parse(path, string(data), format)
+}
+
+// Parse parses a template and renders it once against a sample job, so
+// errors that only show at execution, such as a misspelled field, are
+// reported when the config is loaded rather than on the first match
+func Parse(name, src string, format Format) (*Template, error) {
+	t := &Template{name: name, format: format}
+
+	var err error
+	if format == HTML {
+		t.html, err = htmltemplate.New(name).Funcs(htmltemplate.FuncMap(funcs(format))).Parse(src)
+	} else {
+		t.text, err = texttemplate.New(name).Funcs(funcs(format)).Parse(src)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", name, err)
+	}
+
+	if _, err := t.Render(sampleJob()); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Render executes the template for a matched job. Plain templates are
+// collapsed to a single line and Markdown and Text ones trimmed.
+func (t *Template) Render(matched *model.MatchedJob) (string, error) {
+	var buf bytes.Buffer
+	var err error
+	if t.html != nil {
+		err = t.html.Execute(&buf, matched)
+	} else {
+		err = t.text.Execute(&buf, matched)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", t.name, err)
+	}
+
+	switch t.format {
+	case Plain:
+		return strings.Join(strings.Fields(buf.String()), " "), nil
+	case Markdown, Text:
+		return strings.TrimSpace(buf.String()), nil
+	}
+	return buf.String(), nil
+}
+
+// funcs returns the helper functions available to templates of a format
+func funcs(format Format) texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"truncate":    Truncate,
+		"escapeMD":    EscapeMD,
+		"escapeMDURL": EscapeMDURL,
+		"budget": func(job *model.Job) string {
+			return job.BudgetDisplay()
+		},
+		"ago": model.TimeAgo,
+		"highlight": func(keywords []string, text string) interface{} {
+			switch format {
+			case Markdown:
+				return highlight(keywords, text, EscapeMD, "*", "*")
+			case HTML:
+				return htmltemplate.HTML(highlight(keywords, text, htmltemplate.HTMLEscapeString, "<b>", "</b>"))
+			default:
+				return text
+			}
+		},
+		"join": func(sep string, list []string) string {
+			return strings.Join(list, sep)
+		},
+		"first": func(n int, list []string) []string {
+			if len(list) > n {
+				return list[:n]
+			}
+			return list
+		},
+		"language": langdetect.Name,
+	}
+}
+
+// Truncate cuts s to at most n characters, ending with "..." when cut, so
+// the result always fits a length limit of n. Arguments are ordered for
+// pipelines: {{ .Job.Title | truncate 50 }}.
+func Truncate(n int, s string) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string([]rune(s)[:n])
+	}
+	return string([]rune(s)[:n-3]) + "..."
+}
+
+// EscapeMD escapes special characters for Telegram MarkdownV2
+func EscapeMD(text string) string {
+	chars := []string{"\\", "_", "*", "[", "]", "(", ")", "~", "`", ">", "#", "+", "-", "=", "|", "{", "}", ".", "!"}
+	for _, char := range chars {
+		text = strings.ReplaceAll(text, char, "\\"+char)
+	}
+	return text
+}
+
+// EscapeMDURL escapes a URL for the (...) part of a MarkdownV2 inline
+// link, where only ")" and backslashes are special
+func EscapeMDURL(url string) string {
+	return strings.NewReplacer("\\", "\\\\", ")", "\\)").Replace(url)
+}
+
+// highlight escapes text and wraps every case-insensitive occurrence of
+// a keyword in open and close
+func highlight(keywords []string, text string, escape func(string) string, open, close string) string {
+	var words []string
+	for _, kw := range keywords {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			words = append(words, regexp.QuoteMeta(kw))
+		}
+	}
+	if len(words) == 0 {
+		return escape(text)
+	}
+	// Longest first, so "go api" wins over "go"
+	sort.Slice(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	re := regexp.MustCompile("(?i)" + strings.Join(words, "|"))
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		sb.WriteString(escape(text[last:loc[0]]))
+		sb.WriteString(open + escape(text[loc[0]:loc[1]]) + close)
+		last = loc[1]
+	}
+	sb.WriteString(escape(text[last:]))
+	return sb.String()
+}
+
+// sampleJob is the job templates are test-rendered against
+func sampleJob() *model.MatchedJob {
+	budget := 500.0
+	proposals := 5
+	rating := 4.9
+	matched := model.NewMatchedJob(&model.Job{
+		ID:            "~0sample",
+		Title:         "Sample job",
+		Description:   "A sample job used to check templates",
+		URL:           "https://www.upwork.com/jobs/~0sample",
+		JobType:       model.JobTypeFixed,
+		BudgetMax:     &budget,
+		Proposals:     &proposals,
+		ClientCountry: "United States",
+		ClientRating:  &rating,
+		Skills:        []string{"Go"},
+		Language:      "en",
+		PostedAt:      time.Now(),
+		FetchedAt:     time.Now(),
+	}, []string{"sample"}, "Sample", 1)
+	matched.AddMatch("Other", []string{"job"}, 0.5)
+	return matched
+}
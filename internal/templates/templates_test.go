@@ -0,0 +1,163 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jobradar/internal/model"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		n    int
+		in   string
+		want string
+	}{
+		{10, "short", "short"},
+		{7, "exactly", "exactly"},
+		{6, "exactly", "exa..."},
+		{5, "日本語のテキスト", "日本..."},
+		{2, "日本語のテキスト", "日本"},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.n, tt.in); got != tt.want {
+			t.Errorf("Truncate(%d, %q) = %q, want %q", tt.n, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeMD(t *testing.T) {
+	if got, want := EscapeMD(`C:\ $5.00 (fixed) [v2]!`), `C:\\ $5\.00 \(fixed\) \[v2\]\!`; got != want {
+		t.Errorf("EscapeMD() = %q, want %q", got, want)
+	}
+}
+
+func TestEscapeMDURL(t *testing.T) {
+	if got, want := EscapeMDURL(`https://example.com/a_(b)\c?q=1.2`), `https://example.com/a_(b\)\\c?q=1.2`; got != want {
+		t.Errorf("EscapeMDURL() = %q, want %q", got, want)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	matched := sampleJob()
+	matched.Job.Description = "Build a Go API (REST) in golang"
+	matched.MatchedKeywords = []string{"go", "go api"}
+
+	md, err := Parse("md", `{{ highlight .MatchedKeywords .Job.Description }}`, Markdown)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got, _ := md.Render(matched)
+	if want := `Build a *Go API* \(REST\) in *go*lang`; got != want {
+		t.Errorf("markdown highlight = %q, want %q", got, want)
+	}
+
+	html, err := Parse("html", `<p>{{ highlight .MatchedKeywords .Job.Description }}</p>`, HTML)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	matched.Job.Description = "Go <script> dev"
+	got, _ = html.Render(matched)
+	if want := `<p><b>Go</b> &lt;script&gt; dev</p>`; got != want {
+		t.Errorf("html highlight = %q, want %q", got, want)
+	}
+}
+
+func TestDefaults_Render(t *testing.T) {
+	budget := 1200.0
+	matched := model.NewMatchedJob(&model.Job{
+		Title:       "Senior Go developer (remote)",
+		Description: strings.Repeat("é", 300),
+		URL:         "https://www.upwork.com/jobs/~01",
+		JobType:     model.JobTypeFixed,
+		BudgetMax:   &budget,
+		Skills:      []string{"Go", "Docker", "AWS", "gRPC", "Redis", "Kafka"},
+	}, []string{"golang"}, "Backend", 1)
+
+	telegram, err := Telegram("")
+	if err != nil {
+		t.Fatalf("Telegram() error = %v", err)
+	}
+	msg, err := telegram.Render(matched)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{
+		`📋 *Senior Go developer \(remote\)*`,
+		`💰 $1200 \(Fixed\)`,
+		"👥 Proposals: N/A",
+		"🏷️ Skills: Go, Docker, AWS, gRPC, Redis\n",
+		"📝 " + strings.Repeat("é", 197) + `\.\.\.`,
+		"✅ Matched: golang",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("telegram message does not contain %q:\n%s", want, msg)
+		}
+	}
+	if strings.Contains(msg, "🔎") {
+		t.Error("telegram message lists searches for a single match")
+	}
+
+	subject, _ := EmailSubject("")
+	if got, _ := subject.Render(matched); got != "[JobRadar] New Match: Senior Go developer (remote)" {
+		t.Errorf("subject = %q", got)
+	}
+
+	body, _ := EmailBody("")
+	html, err := body.Render(matched)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(html, `<a href="https://www.upwork.com/jobs/~01"`) {
+		t.Errorf("email body does not link the job:\n%s", html)
+	}
+
+	text, _ := EmailText("")
+	plain, err := text.Render(matched)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{
+		"New job match: Senior Go developer (remote)\n",
+		"Budget: $1200 (Fixed)\n",
+		"View job: https://www.upwork.com/jobs/~01\n",
+	} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("email text does not contain %q:\n%s", want, plain)
+		}
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	if _, err := Telegram(filepath.Join(dir, "missing.tmpl")); err == nil {
+		t.Error("Telegram() should fail for a missing file")
+	}
+	if _, err := Telegram(write("syntax.tmpl", "{{ .Job.Title ")); err == nil {
+		t.Error("Telegram() should fail for a template that does not parse")
+	}
+	if _, err := EmailBody(write("field.html", "{{ .Job.Titel }}")); err == nil {
+		t.Error("EmailBody() should fail for an unknown field")
+	}
+	if _, err := EmailSubject("{{ nosuchfunc .Job.Title }}"); err == nil {
+		t.Error("EmailSubject() should fail for an unknown function")
+	}
+
+	tmpl, err := Telegram(write("custom.tmpl", "*{{ escapeMD .Job.Title }}* {{ ago .Job.PostedAt }}\n"))
+	if err != nil {
+		t.Fatalf("Telegram() error = %v", err)
+	}
+	if got, _ := tmpl.Render(sampleJob()); got != "*Sample job* just now" {
+		t.Errorf("custom template = %q", got)
+	}
+}